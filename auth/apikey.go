@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type KeyStore interface {
+	Lookup(ctx context.Context, hash string) (*Principal, error)
+}
+
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func NewMemoryKeyStore(hashes map[string]string) *MemoryKeyStore {
+	store := &MemoryKeyStore{subjects: make(map[string]string, len(hashes))}
+
+	for hash, subject := range hashes {
+		store.subjects[strings.ToLower(hash)] = subject
+	}
+
+	return store
+}
+
+type MemoryKeyStore struct {
+	mu       sync.RWMutex
+	subjects map[string]string
+}
+
+func (store *MemoryKeyStore) Add(key, subject string) {
+	store.mu.Lock()
+
+	defer store.mu.Unlock()
+
+	store.subjects[HashKey(key)] = subject
+}
+
+func (store *MemoryKeyStore) Revoke(key string) {
+	store.mu.Lock()
+
+	defer store.mu.Unlock()
+
+	delete(store.subjects, HashKey(key))
+}
+
+func (store *MemoryKeyStore) Lookup(ctx context.Context, hash string) (*Principal, error) {
+	store.mu.RLock()
+
+	defer store.mu.RUnlock()
+
+	subject, ok := store.subjects[hash]
+
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Subject: subject}, nil
+}
+
+func APIKey(config APIKeyConfig) Authenticator {
+	cfg := saneAPIKeyConfig(config)
+
+	return &apiKey{config: cfg}
+}
+
+func saneAPIKeyConfig(in APIKeyConfig) APIKeyConfig {
+	out := APIKeyConfig{
+		Header: in.Header,
+		Query:  in.Query,
+		Store:  in.Store,
+	}
+
+	if out.Header == "" && out.Query == "" {
+		out.Header = "X-API-Key"
+	}
+
+	if out.Store == nil {
+		panic("auth: api key requires a Store")
+	}
+
+	return out
+}
+
+type APIKeyConfig struct {
+	Header string
+	Query  string
+	Store  KeyStore
+}
+
+type apiKey struct {
+	config APIKeyConfig
+}
+
+func (a *apiKey) Authenticate(r *http.Request) (*Principal, error) {
+	key := a.key(r)
+
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	principal, err := a.config.Store.Lookup(r.Context(), HashKey(key))
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := *principal
+
+	result.Scheme = "APIKey"
+
+	return &result, nil
+}
+
+func (a *apiKey) key(r *http.Request) string {
+	if a.config.Header != "" {
+		key := strings.TrimSpace(r.Header.Get(a.config.Header))
+
+		if key != "" {
+			return key
+		}
+	}
+
+	if a.config.Query != "" {
+		return strings.TrimSpace(r.URL.Query().Get(a.config.Query))
+	}
+
+	return ""
+}
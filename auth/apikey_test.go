@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIKey(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryKeyStore(map[string]string{
+		HashKey("reader-key"): "reader",
+	})
+
+	store.Add("writer-key", "writer")
+
+	store.Add("revoked-key", "revoked")
+
+	store.Revoke("revoked-key")
+
+	tests := []struct {
+		name        string
+		config      APIKeyConfig
+		target      string
+		header      string
+		wantSubject string
+		wantErr     error
+	}{
+		{
+			name:        "should read the default header",
+			config:      APIKeyConfig{Store: store},
+			target:      "/",
+			header:      "reader-key",
+			wantSubject: "reader",
+		},
+		{
+			name:        "should read added keys",
+			config:      APIKeyConfig{Store: store},
+			target:      "/",
+			header:      "writer-key",
+			wantSubject: "writer",
+		},
+		{
+			name:        "should fall back to the query",
+			config:      APIKeyConfig{Header: "X-API-Key", Query: "api_key", Store: store},
+			target:      "/?api_key=reader-key",
+			wantSubject: "reader",
+		},
+		{
+			name:    "should ignore the query unless configured",
+			config:  APIKeyConfig{Store: store},
+			target:  "/?api_key=reader-key",
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:    "should reject unknown keys",
+			config:  APIKeyConfig{Store: store},
+			target:  "/",
+			header:  "guess",
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name:    "should reject revoked keys",
+			config:  APIKeyConfig{Store: store},
+			target:  "/",
+			header:  "revoked-key",
+			wantErr: ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+		if tt.header != "" {
+			r.Header.Set("X-API-Key", tt.header)
+		}
+
+		principal, err := APIKey(tt.config).Authenticate(r)
+
+		assert.ErrorIsf(t, err, tt.wantErr, "%s: want %v, got %v", tt.name, tt.wantErr, err)
+
+		if tt.wantErr != nil {
+			continue
+		}
+
+		want := &Principal{Subject: tt.wantSubject, Scheme: "APIKey"}
+
+		assert.Equalf(t, want, principal, "%s: want %v, got %v", tt.name, want, principal)
+	}
+}
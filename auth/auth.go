@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	h "github.com/aakash-rajur/http"
+	"net/http"
+)
+
+type Principal struct {
+	Subject string
+	Scheme  string
+	Claims  map[string]any
+	Factors []*Principal
+}
+
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+type AuthenticatorFunc func(r *http.Request) (*Principal, error)
+
+func (fn AuthenticatorFunc) Authenticate(r *http.Request) (*Principal, error) {
+	return fn(r)
+}
+
+type Challenger interface {
+	Challenge(err error) string
+}
+
+func New(config Config) h.Middleware {
+	return func(w http.ResponseWriter, r *http.Request, next h.Next) {
+		authenticator, optional := config.Authenticator, config.Optional
+
+		route, ok := h.RouteFromRequest(r)
+
+		if ok {
+			value, found := route.Value(authenticatorKey)
+
+			if found {
+				authenticator, _ = value.(Authenticator)
+			}
+
+			value, found = route.Value(optionalKey)
+
+			if found {
+				optional = value.(bool)
+			}
+		}
+
+		if authenticator == nil {
+			next(r)
+
+			return
+		}
+
+		principal, err := authenticator.Authenticate(r)
+
+		if err == nil && principal != nil {
+			next(r.WithContext(WithPrincipal(r.Context(), principal)))
+
+			return
+		}
+
+		if err == nil {
+			err = ErrNoCredentials
+		}
+
+		if optional && errors.Is(err, ErrNoCredentials) {
+			next(r)
+
+			return
+		}
+
+		if !errors.Is(err, ErrNoCredentials) && !errors.Is(err, ErrInvalidCredentials) {
+			h.HandleError(w, r, err)
+
+			return
+		}
+
+		challenger, ok := authenticator.(Challenger)
+
+		if ok {
+			challenge := challenger.Challenge(err)
+
+			if challenge != "" {
+				w.Header().Set("WWW-Authenticate", challenge)
+			}
+		}
+
+		message := "invalid credentials"
+
+		if errors.Is(err, ErrNoCredentials) {
+			message = "authentication required"
+		}
+
+		h.HandleError(w, r, &h.HTTPError{Code: http.StatusUnauthorized, Message: message, Err: err})
+	}
+}
+
+func Require(authenticator Authenticator) h.RouteOption {
+	return h.WithMetadata(authenticatorKey, authenticator)
+}
+
+func Public() h.RouteOption {
+	return h.WithMetadata(authenticatorKey, nil)
+}
+
+func Optional(optional bool) h.RouteOption {
+	return h.WithMetadata(optionalKey, optional)
+}
+
+type Config struct {
+	Authenticator Authenticator
+	Optional      bool
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(*Principal)
+
+	return principal, ok && principal != nil
+}
+
+func PrincipalFromRequest(r *http.Request) (*Principal, bool) {
+	return PrincipalFromContext(r.Context())
+}
+
+var (
+	ErrNoCredentials      = errors.New("auth: no credentials")
+	ErrInvalidCredentials = errors.New("auth: invalid credentials")
+)
+
+const (
+	authenticatorKey = "http_auth_authenticator"
+	optionalKey      = "http_auth_optional"
+	principalKey     = "http_auth_principal"
+)
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	h "github.com/aakash-rajur/http"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type failingKeyStore struct{}
+
+func (failingKeyStore) Lookup(ctx context.Context, hash string) (*Principal, error) {
+	return nil, errors.New("key store unavailable")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryKeyStore(nil)
+
+	store.Add("secret", "service")
+
+	basic := Basic(BasicConfig{Realm: "books", Users: map[string]string{"alice": "wonderland"}})
+
+	apiKey := APIKey(APIKeyConfig{Store: store})
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{Authenticator: AnyOf(basic, apiKey)}))
+
+	whoami := func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := PrincipalFromRequest(r)
+
+		if !ok {
+			_, _ = w.Write([]byte("anonymous"))
+
+			return
+		}
+
+		schemes := make([]string, 0, len(principal.Factors))
+
+		for _, factor := range principal.Factors {
+			schemes = append(schemes, factor.Scheme)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s|%s|%s", principal.Subject, principal.Scheme, strings.Join(schemes, "+"))
+	}
+
+	router.GetFunc("/books", whoami)
+
+	router.GetFunc("/health", whoami, Public())
+
+	router.GetFunc("/catalog", whoami, Optional(true))
+
+	router.GetFunc("/admin", whoami, Require(AllOf(basic, apiKey)))
+
+	router.GetFunc("/billing", whoami, Require(APIKey(APIKeyConfig{Store: failingKeyStore{}})))
+
+	tests := []struct {
+		name          string
+		target        string
+		basic         []string
+		apiKey        string
+		wantStatus    int
+		wantBody      string
+		wantChallenge string
+	}{
+		{
+			name:       "should authenticate with basic",
+			target:     "/books",
+			basic:      []string{"alice", "wonderland"},
+			wantStatus: http.StatusOK,
+			wantBody:   "alice|Basic|",
+		},
+		{
+			name:       "should authenticate with api key",
+			target:     "/books",
+			apiKey:     "secret",
+			wantStatus: http.StatusOK,
+			wantBody:   "service|APIKey|",
+		},
+		{
+			name:          "should challenge anonymous requests",
+			target:        "/books",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"detail":"authentication required","instance":"/books","status":401,"title":"Unauthorized"}`,
+			wantChallenge: `Basic realm="books", charset="UTF-8"`,
+		},
+		{
+			name:          "should reject invalid credentials",
+			target:        "/books",
+			basic:         []string{"alice", "looking-glass"},
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"detail":"invalid credentials","instance":"/books","status":401,"title":"Unauthorized"}`,
+			wantChallenge: `Basic realm="books", charset="UTF-8"`,
+		},
+		{
+			name:       "should skip public routes",
+			target:     "/health",
+			wantStatus: http.StatusOK,
+			wantBody:   "anonymous",
+		},
+		{
+			name:       "should allow anonymous requests on optional routes",
+			target:     "/catalog",
+			wantStatus: http.StatusOK,
+			wantBody:   "anonymous",
+		},
+		{
+			name:       "should still authenticate optional routes",
+			target:     "/catalog",
+			apiKey:     "secret",
+			wantStatus: http.StatusOK,
+			wantBody:   "service|APIKey|",
+		},
+		{
+			name:          "should reject invalid credentials on optional routes",
+			target:        "/catalog",
+			apiKey:        "guess",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"detail":"invalid credentials","instance":"/catalog","status":401,"title":"Unauthorized"}`,
+			wantChallenge: `Basic realm="books", charset="UTF-8"`,
+		},
+		{
+			name:       "should require every factor",
+			target:     "/admin",
+			basic:      []string{"alice", "wonderland"},
+			apiKey:     "secret",
+			wantStatus: http.StatusOK,
+			wantBody:   "alice|Basic|Basic+APIKey",
+		},
+		{
+			name:          "should reject a missing factor",
+			target:        "/admin",
+			basic:         []string{"alice", "wonderland"},
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"detail":"authentication required","instance":"/admin","status":401,"title":"Unauthorized"}`,
+			wantChallenge: `Basic realm="books", charset="UTF-8"`,
+		},
+		{
+			name:       "should not treat store failures as bad credentials",
+			target:     "/billing",
+			apiKey:     "secret",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"instance":"/billing","status":500,"title":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+		if tt.basic != nil {
+			r.SetBasicAuth(tt.basic[0], tt.basic[1])
+		}
+
+		if tt.apiKey != "" {
+			r.Header.Set("X-API-Key", tt.apiKey)
+		}
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, r)
+
+		assert.Equalf(t, tt.wantStatus, w.Code, "%s: want %v, got %v", tt.name, tt.wantStatus, w.Code)
+
+		if strings.HasPrefix(tt.wantBody, "{") {
+			assert.JSONEqf(t, tt.wantBody, w.Body.String(), "%s: want %v, got %v", tt.name, tt.wantBody, w.Body.String())
+		} else {
+			assert.Equalf(t, tt.wantBody, w.Body.String(), "%s: want %v, got %v", tt.name, tt.wantBody, w.Body.String())
+		}
+
+		gotChallenge := w.Header().Get("WWW-Authenticate")
+
+		assert.Equalf(t, tt.wantChallenge, gotChallenge, "%s: want %v, got %v", tt.name, tt.wantChallenge, gotChallenge)
+	}
+}
+
+func TestAnyOf(t *testing.T) {
+	t.Parallel()
+
+	none := AuthenticatorFunc(func(r *http.Request) (*Principal, error) {
+		return nil, ErrNoCredentials
+	})
+
+	invalid := AuthenticatorFunc(func(r *http.Request) (*Principal, error) {
+		return nil, ErrInvalidCredentials
+	})
+
+	user := AuthenticatorFunc(func(r *http.Request) (*Principal, error) {
+		return &Principal{Subject: "user"}, nil
+	})
+
+	tests := []struct {
+		name          string
+		authenticator Authenticator
+		wantSubject   string
+		wantErr       error
+	}{
+		{name: "should pick the first success", authenticator: AnyOf(none, invalid, user), wantSubject: "user"},
+		{name: "should prefer invalid over missing", authenticator: AnyOf(none, invalid), wantErr: ErrInvalidCredentials},
+		{name: "should report missing credentials", authenticator: AnyOf(none, none), wantErr: ErrNoCredentials},
+		{name: "should report missing credentials without authenticators", authenticator: AnyOf(), wantErr: ErrNoCredentials},
+		{name: "should fail all of on any failure", authenticator: AllOf(user, invalid), wantErr: ErrInvalidCredentials},
+		{name: "should require all of to have authenticators", authenticator: AllOf(), wantErr: ErrNoCredentials},
+		{name: "should nest combinators", authenticator: AllOf(AnyOf(none, user), user), wantSubject: "user"},
+	}
+
+	for _, tt := range tests {
+		principal, err := tt.authenticator.Authenticate(httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.ErrorIsf(t, err, tt.wantErr, "%s: want %v, got %v", tt.name, tt.wantErr, err)
+
+		if tt.wantErr != nil {
+			continue
+		}
+
+		assert.Equalf(t, tt.wantSubject, principal.Subject, "%s: want %v, got %v", tt.name, tt.wantSubject, principal.Subject)
+	}
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+)
+
+func Basic(config BasicConfig) Authenticator {
+	cfg := saneBasicConfig(config)
+
+	users := make(map[string][32]byte, len(cfg.Users))
+
+	for user, password := range cfg.Users {
+		users[user] = sha256.Sum256([]byte(password))
+	}
+
+	return &basic{config: cfg, users: users}
+}
+
+func saneBasicConfig(in BasicConfig) BasicConfig {
+	out := BasicConfig{
+		Realm:    "restricted",
+		Users:    in.Users,
+		Validate: in.Validate,
+	}
+
+	if in.Realm != "" {
+		out.Realm = in.Realm
+	}
+
+	if out.Users == nil && out.Validate == nil {
+		panic("auth: basic requires Users or Validate")
+	}
+
+	return out
+}
+
+type BasicConfig struct {
+	Realm    string
+	Users    map[string]string
+	Validate func(r *http.Request, user, password string) bool
+}
+
+type basic struct {
+	config BasicConfig
+	users  map[string][32]byte
+}
+
+func (b *basic) Authenticate(r *http.Request) (*Principal, error) {
+	user, password, ok := r.BasicAuth()
+
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	if !b.valid(r, user, password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Subject: user, Scheme: "Basic"}, nil
+}
+
+func (b *basic) Challenge(err error) string {
+	return fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", b.config.Realm)
+}
+
+func (b *basic) valid(r *http.Request, user, password string) bool {
+	if b.config.Validate != nil {
+		return b.config.Validate(r, user, password)
+	}
+
+	want, ok := b.users[user]
+
+	got := sha256.Sum256([]byte(password))
+
+	match := subtle.ConstantTimeCompare(want[:], got[:]) == 1
+
+	return ok && match
+}
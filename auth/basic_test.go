@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBasic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      BasicConfig
+		credentials []string
+		wantSubject string
+		wantErr     error
+	}{
+		{
+			name:        "should accept known users",
+			config:      BasicConfig{Users: map[string]string{"alice": "wonderland"}},
+			credentials: []string{"alice", "wonderland"},
+			wantSubject: "alice",
+		},
+		{
+			name:        "should reject wrong passwords",
+			config:      BasicConfig{Users: map[string]string{"alice": "wonderland"}},
+			credentials: []string{"alice", "wonderlan"},
+			wantErr:     ErrInvalidCredentials,
+		},
+		{
+			name:        "should reject unknown users",
+			config:      BasicConfig{Users: map[string]string{"alice": "wonderland"}},
+			credentials: []string{"bob", "wonderland"},
+			wantErr:     ErrInvalidCredentials,
+		},
+		{
+			name:    "should report missing credentials",
+			config:  BasicConfig{Users: map[string]string{"alice": "wonderland"}},
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name: "should delegate to validate",
+			config: BasicConfig{
+				Validate: func(r *http.Request, user, password string) bool {
+					return user == password
+				},
+			},
+			credentials: []string{"carol", "carol"},
+			wantSubject: "carol",
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		if tt.credentials != nil {
+			r.SetBasicAuth(tt.credentials[0], tt.credentials[1])
+		}
+
+		principal, err := Basic(tt.config).Authenticate(r)
+
+		assert.ErrorIsf(t, err, tt.wantErr, "%s: want %v, got %v", tt.name, tt.wantErr, err)
+
+		if tt.wantErr != nil {
+			continue
+		}
+
+		want := &Principal{Subject: tt.wantSubject, Scheme: "Basic"}
+
+		assert.Equalf(t, want, principal, "%s: want %v, got %v", tt.name, want, principal)
+	}
+
+	challenge := Basic(BasicConfig{Realm: "books", Users: map[string]string{}}).(Challenger).Challenge(ErrNoCredentials)
+
+	assert.Equalf(t, `Basic realm="books", charset="UTF-8"`, challenge, "want %v, got %v", `Basic realm="books", charset="UTF-8"`, challenge)
+
+	assert.PanicsWithValue(t, "auth: basic requires Users or Validate", func() {
+		Basic(BasicConfig{})
+	})
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+func AnyOf(authenticators ...Authenticator) Authenticator {
+	return anyOf(authenticators)
+}
+
+type anyOf []Authenticator
+
+func (authenticators anyOf) Authenticate(r *http.Request) (*Principal, error) {
+	var failure error
+
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(r)
+
+		if err == nil && principal != nil {
+			return principal, nil
+		}
+
+		if err != nil && !errors.Is(err, ErrNoCredentials) && failure == nil {
+			failure = err
+		}
+	}
+
+	if failure != nil {
+		return nil, failure
+	}
+
+	return nil, ErrNoCredentials
+}
+
+func (authenticators anyOf) Challenge(err error) string {
+	return challenges(authenticators, err)
+}
+
+func AllOf(authenticators ...Authenticator) Authenticator {
+	return allOf(authenticators)
+}
+
+type allOf []Authenticator
+
+func (authenticators allOf) Authenticate(r *http.Request) (*Principal, error) {
+	factors := make([]*Principal, 0, len(authenticators))
+
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if principal == nil {
+			return nil, ErrNoCredentials
+		}
+
+		factors = append(factors, principal)
+	}
+
+	if len(factors) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	principal := *factors[0]
+
+	principal.Factors = factors
+
+	return &principal, nil
+}
+
+func (authenticators allOf) Challenge(err error) string {
+	return challenges(authenticators, err)
+}
+
+func challenges(authenticators []Authenticator, err error) string {
+	values := make([]string, 0, len(authenticators))
+
+	for _, authenticator := range authenticators {
+		challenger, ok := authenticator.(Challenger)
+
+		if !ok {
+			continue
+		}
+
+		challenge := challenger.Challenge(err)
+
+		if challenge != "" {
+			values = append(values, challenge)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type KeySet interface {
+	Key(kid, alg string) (any, error)
+}
+
+func LoadJWKS(path string) (*JWKS, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJWKS(data)
+}
+
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	err := json.Unmarshal(data, &document)
+
+	if err != nil {
+		return nil, fmt.Errorf("auth: invalid jwks: %w", err)
+	}
+
+	set := &JWKS{keys: make([]parsedKey, 0, len(document.Keys))}
+
+	for i, each := range document.Keys {
+		if each.Use != "" && each.Use != "sig" {
+			continue
+		}
+
+		key, err := each.parse()
+
+		if errors.Is(err, errUnsupportedKeyType) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("auth: invalid jwk %d: %w", i, err)
+		}
+
+		set.keys = append(set.keys, parsedKey{kid: each.Kid, alg: each.Alg, key: key})
+	}
+
+	return set, nil
+}
+
+type JWKS struct {
+	keys []parsedKey
+}
+
+type parsedKey struct {
+	kid string
+	alg string
+	key any
+}
+
+func (set *JWKS) Key(kid, alg string) (any, error) {
+	for _, each := range set.keys {
+		if kid != "" && each.kid != kid {
+			continue
+		}
+
+		if each.alg != "" && each.alg != alg {
+			continue
+		}
+
+		if keyFits(each.key, alg) {
+			return each.key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
+
+func (set *JWKS) Len() int {
+	return len(set.keys)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+func (key jwk) parse() (any, error) {
+	switch key.Kty {
+	case "oct":
+		k, err := decodeSegment(key.K)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(k) == 0 {
+			return nil, errors.New("empty oct key")
+		}
+
+		return k, nil
+
+	case "RSA":
+		n, err := decodeSegment(key.N)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeSegment(key.E)
+
+		if err != nil {
+			return nil, err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("malformed rsa key")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+
+	case "EC":
+		if key.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+
+		x, err := decodeSegment(key.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeSegment(key.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("malformed ec key")
+		}
+
+		_, err = ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...))
+
+		if err != nil {
+			return nil, errors.New("malformed ec key")
+		}
+
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+
+	case "OKP":
+		if key.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+
+		x, err := decodeSegment(key.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("malformed ed25519 key")
+		}
+
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
+
+func keyFits(key any, alg string) bool {
+	switch key.(type) {
+	case []byte:
+		return alg == "HS256"
+
+	case *rsa.PublicKey:
+		return alg == "RS256"
+
+	case *ecdsa.PublicKey:
+		return alg == "ES256"
+
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+
+	default:
+		return false
+	}
+}
+
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(segment)
+}
+
+var errUnsupportedKeyType = errors.New("unsupported key type")
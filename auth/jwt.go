@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+func JWT(config JWTConfig) *JWTAuthenticator {
+	cfg := saneJWTConfig(config)
+
+	return &JWTAuthenticator{config: cfg}
+}
+
+func saneJWTConfig(in JWTConfig) JWTConfig {
+	out := JWTConfig{
+		Keys:               in.Keys,
+		Issuer:             in.Issuer,
+		Audience:           in.Audience,
+		Algorithms:         []string{"HS256", "RS256", "ES256", "EdDSA"},
+		Leeway:             in.Leeway,
+		AllowMissingExpiry: in.AllowMissingExpiry,
+		Realm:              in.Realm,
+		Now:                time.Now,
+	}
+
+	if out.Keys == nil {
+		panic("auth: jwt requires Keys")
+	}
+
+	if len(in.Algorithms) > 0 {
+		out.Algorithms = in.Algorithms
+	}
+
+	if in.Now != nil {
+		out.Now = in.Now
+	}
+
+	return out
+}
+
+type JWTConfig struct {
+	Keys               KeySet
+	Issuer             string
+	Audience           string
+	Algorithms         []string
+	Leeway             time.Duration
+	AllowMissingExpiry bool
+	Realm              string
+	Now                func() time.Time
+}
+
+type JWTAuthenticator struct {
+	config JWTConfig
+}
+
+func (j *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := j.Verify(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subject, _ := claims["sub"].(string)
+
+	return &Principal{Subject: subject, Scheme: "Bearer", Claims: claims}, nil
+}
+
+func (j *JWTAuthenticator) Challenge(err error) string {
+	params := make([]string, 0, 2)
+
+	if j.config.Realm != "" {
+		params = append(params, fmt.Sprintf("realm=%q", j.config.Realm))
+	}
+
+	if err != nil && !errors.Is(err, ErrNoCredentials) {
+		params = append(params, `error="invalid_token"`)
+	}
+
+	if len(params) == 0 {
+		return "Bearer"
+	}
+
+	return "Bearer " + strings.Join(params, ", ")
+}
+
+func (j *JWTAuthenticator) Verify(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header struct {
+		Alg  string   `json:"alg"`
+		Kid  string   `json:"kid"`
+		Crit []string `json:"crit"`
+	}
+
+	err := decodeJSONSegment(parts[0], &header)
+
+	if err != nil || len(header.Crit) > 0 {
+		return nil, ErrMalformedToken
+	}
+
+	if !slices.Contains(j.config.Algorithms, header.Alg) {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	key, err := j.config.Keys.Key(header.Kid, header.Alg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := decodeSegment(parts[2])
+
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if !verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	claims := make(map[string]any)
+
+	err = decodeJSONSegment(parts[1], &claims)
+
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	err = j.validate(claims)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (j *JWTAuthenticator) validate(claims map[string]any) error {
+	cfg := j.config
+
+	now := cfg.Now()
+
+	exp, ok, err := numericDate(claims, "exp")
+
+	if err != nil {
+		return err
+	}
+
+	if !ok && !cfg.AllowMissingExpiry {
+		return ErrMissingExpiry
+	}
+
+	if ok && !now.Before(exp.Add(cfg.Leeway)) {
+		return ErrTokenExpired
+	}
+
+	nbf, ok, err := numericDate(claims, "nbf")
+
+	if err != nil {
+		return err
+	}
+
+	if ok && now.Add(cfg.Leeway).Before(nbf) {
+		return ErrTokenNotYetValid
+	}
+
+	if cfg.Issuer != "" && claims["iss"] != cfg.Issuer {
+		return ErrInvalidIssuer
+	}
+
+	if cfg.Audience != "" && !hasAudience(claims["aud"], cfg.Audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func verifySignature(alg string, key any, signed, signature []byte) bool {
+	digest := sha256.Sum256(signed)
+
+	switch alg {
+	case "HS256":
+		secret, ok := key.([]byte)
+
+		if !ok {
+			return false
+		}
+
+		mac := hmac.New(sha256.New, secret)
+
+		mac.Write(signed)
+
+		return hmac.Equal(mac.Sum(nil), signature)
+
+	case "RS256":
+		public, ok := key.(*rsa.PublicKey)
+
+		return ok && rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature) == nil
+
+	case "ES256":
+		public, ok := key.(*ecdsa.PublicKey)
+
+		if !ok || len(signature) != 64 {
+			return false
+		}
+
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+
+		return ecdsa.Verify(public, digest[:], r, s)
+
+	case "EdDSA":
+		public, ok := key.(ed25519.PublicKey)
+
+		return ok && ed25519.Verify(public, signed, signature)
+
+	default:
+		return false
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+func decodeJSONSegment(segment string, value any) error {
+	data, err := decodeSegment(segment)
+
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
+
+func numericDate(claims map[string]any, name string) (time.Time, bool, error) {
+	value, ok := claims[name]
+
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	number, ok := value.(json.Number)
+
+	if !ok {
+		return time.Time{}, false, ErrMalformedToken
+	}
+
+	seconds, err := number.Float64()
+
+	if err != nil || math.Abs(seconds) > maxNumericDate {
+		return time.Time{}, false, ErrMalformedToken
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), true, nil
+}
+
+func hasAudience(claim any, audience string) bool {
+	switch value := claim.(type) {
+	case string:
+		return value == audience
+
+	case []any:
+		for _, each := range value {
+			if each == audience {
+				return true
+			}
+		}
+
+		return false
+
+	default:
+		return false
+	}
+}
+
+var (
+	ErrMalformedToken       = fmt.Errorf("%w: malformed token", ErrInvalidCredentials)
+	ErrUnsupportedAlgorithm = fmt.Errorf("%w: unsupported algorithm", ErrInvalidCredentials)
+	ErrUnknownKey           = fmt.Errorf("%w: unknown key", ErrInvalidCredentials)
+	ErrInvalidSignature     = fmt.Errorf("%w: invalid signature", ErrInvalidCredentials)
+	ErrTokenExpired         = fmt.Errorf("%w: token expired", ErrInvalidCredentials)
+	ErrMissingExpiry        = fmt.Errorf("%w: missing expiry", ErrInvalidCredentials)
+	ErrTokenNotYetValid     = fmt.Errorf("%w: token not valid yet", ErrInvalidCredentials)
+	ErrInvalidIssuer        = fmt.Errorf("%w: invalid issuer", ErrInvalidCredentials)
+	ErrInvalidAudience      = fmt.Errorf("%w: invalid audience", ErrInvalidCredentials)
+)
+
+const maxNumericDate = float64(math.MaxInt64 / int64(time.Second))
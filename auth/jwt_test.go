@@ -0,0 +1,341 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type signingKeys struct {
+	hmac    []byte
+	rsa     *rsa.PrivateKey
+	ecdsa   *ecdsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+func newSigningKeys(t *testing.T) signingKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	assert.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	assert.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+
+	assert.NoError(t, err)
+
+	return signingKeys{
+		hmac:    []byte("0123456789abcdef0123456789abcdef"),
+		rsa:     rsaKey,
+		ecdsa:   ecdsaKey,
+		ed25519: ed25519Key,
+	}
+}
+
+func (keys signingKeys) jwks() []byte {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	document := map[string]any{
+		"keys": []map[string]string{
+			{"kty": "oct", "kid": "hs", "k": encode(keys.hmac)},
+			{"kty": "RSA", "kid": "rs", "alg": "RS256", "n": encode(keys.rsa.N.Bytes()), "e": encode(big.NewInt(int64(keys.rsa.E)).Bytes())},
+			{"kty": "EC", "kid": "es", "crv": "P-256", "x": encode(keys.ecdsa.X.FillBytes(make([]byte, 32))), "y": encode(keys.ecdsa.Y.FillBytes(make([]byte, 32)))},
+			{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": encode(keys.ed25519.Public().(ed25519.PublicKey))},
+			{"kty": "RSA", "kid": "enc", "use": "enc", "n": "", "e": ""},
+			{"kty": "unknown", "kid": "other"},
+		},
+	}
+
+	data, _ := json.Marshal(document)
+
+	return data
+}
+
+func (keys signingKeys) sign(alg, kid string, claims map[string]any) string {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+
+	payload, _ := json.Marshal(claims)
+
+	signed := encode(header) + "." + encode(payload)
+
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, keys.hmac)
+
+		mac.Write([]byte(signed))
+
+		signature = mac.Sum(nil)
+
+	case "RS256":
+		signature, _ = rsa.SignPKCS1v15(rand.Reader, keys.rsa, crypto.SHA256, digest[:])
+
+	case "ES256":
+		r, s, _ := ecdsa.Sign(rand.Reader, keys.ecdsa, digest[:])
+
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+	case "EdDSA":
+		signature = ed25519.Sign(keys.ed25519, []byte(signed))
+	}
+
+	return signed + "." + encode(signature)
+}
+
+func tamper(token string, claims map[string]any) string {
+	parts := strings.Split(token, ".")
+
+	payload, _ := json.Marshal(claims)
+
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+
+	return strings.Join(parts, ".")
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	keys := newSigningKeys(t)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+
+	err := os.WriteFile(path, keys.jwks(), 0o600)
+
+	assert.NoError(t, err)
+
+	set, err := LoadJWKS(path)
+
+	assert.NoError(t, err)
+
+	assert.Equalf(t, 4, set.Len(), "want %v, got %v", 4, set.Len())
+
+	now := time.Unix(1700000000, 0)
+
+	authenticator := JWT(JWTConfig{
+		Keys:     set,
+		Issuer:   "https://issuer.example.com",
+		Audience: "books",
+		Leeway:   time.Minute,
+		Realm:    "api",
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	valid := func(overrides map[string]any) map[string]any {
+		claims := map[string]any{
+			"sub": "user-1",
+			"iss": "https://issuer.example.com",
+			"aud": []string{"books", "users"},
+			"exp": now.Add(time.Hour).Unix(),
+			"nbf": now.Add(-time.Hour).Unix(),
+		}
+
+		for key, value := range overrides {
+			if value == nil {
+				delete(claims, key)
+
+				continue
+			}
+
+			claims[key] = value
+		}
+
+		return claims
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantSubject   string
+		wantErr       error
+	}{
+		{
+			name:          "should verify HS256",
+			authorization: "Bearer " + keys.sign("HS256", "hs", valid(nil)),
+			wantSubject:   "user-1",
+		},
+		{
+			name:          "should verify RS256",
+			authorization: "Bearer " + keys.sign("RS256", "rs", valid(nil)),
+			wantSubject:   "user-1",
+		},
+		{
+			name:          "should verify ES256",
+			authorization: "Bearer " + keys.sign("ES256", "es", valid(nil)),
+			wantSubject:   "user-1",
+		},
+		{
+			name:          "should verify EdDSA without kid",
+			authorization: "bearer " + keys.sign("EdDSA", "", valid(nil)),
+			wantSubject:   "user-1",
+		},
+		{
+			name:          "should accept single audience within leeway",
+			authorization: "Bearer " + keys.sign("ES256", "es", valid(map[string]any{"aud": "books", "exp": now.Add(-30 * time.Second).Unix()})),
+			wantSubject:   "user-1",
+		},
+		{
+			name:    "should report missing credentials",
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:          "should reject expired tokens",
+			authorization: "Bearer " + keys.sign("RS256", "rs", valid(map[string]any{"exp": now.Add(-time.Hour).Unix()})),
+			wantErr:       ErrTokenExpired,
+		},
+		{
+			name:          "should reject tokens without expiry",
+			authorization: "Bearer " + keys.sign("HS256", "hs", valid(map[string]any{"exp": nil})),
+			wantErr:       ErrMissingExpiry,
+		},
+		{
+			name:          "should reject out of range expiry",
+			authorization: "Bearer " + keys.sign("HS256", "hs", valid(map[string]any{"exp": 1e300})),
+			wantErr:       ErrMalformedToken,
+		},
+		{
+			name:          "should reject tokens not valid yet",
+			authorization: "Bearer " + keys.sign("RS256", "rs", valid(map[string]any{"nbf": now.Add(time.Hour).Unix()})),
+			wantErr:       ErrTokenNotYetValid,
+		},
+		{
+			name:          "should reject foreign issuers",
+			authorization: "Bearer " + keys.sign("HS256", "hs", valid(map[string]any{"iss": "https://evil.example.com"})),
+			wantErr:       ErrInvalidIssuer,
+		},
+		{
+			name:          "should reject foreign audiences",
+			authorization: "Bearer " + keys.sign("HS256", "hs", valid(map[string]any{"aud": "users"})),
+			wantErr:       ErrInvalidAudience,
+		},
+		{
+			name:          "should reject missing audience",
+			authorization: "Bearer " + keys.sign("HS256", "hs", valid(map[string]any{"aud": nil})),
+			wantErr:       ErrInvalidAudience,
+		},
+		{
+			name:          "should reject tampered signatures",
+			authorization: "Bearer " + keys.sign("EdDSA", "ed", valid(nil)) + "x",
+			wantErr:       ErrInvalidSignature,
+		},
+		{
+			name:          "should reject tampered claims",
+			authorization: "Bearer " + tamper(keys.sign("ES256", "es", valid(nil)), valid(map[string]any{"sub": "admin"})),
+			wantErr:       ErrInvalidSignature,
+		},
+		{
+			name:          "should reject alg none",
+			authorization: "Bearer " + encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(`{"sub":"user-1"}`)) + ".",
+			wantErr:       ErrUnsupportedAlgorithm,
+		},
+		{
+			name:          "should reject algorithm confusion",
+			authorization: "Bearer " + keys.sign("HS256", "rs", valid(nil)),
+			wantErr:       ErrUnknownKey,
+		},
+		{
+			name:          "should reject malformed tokens",
+			authorization: "Bearer abc.def",
+			wantErr:       ErrMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+
+		principal, err := authenticator.Authenticate(r)
+
+		assert.ErrorIsf(t, err, tt.wantErr, "%s: want %v, got %v", tt.name, tt.wantErr, err)
+
+		if tt.wantErr != nil {
+			continue
+		}
+
+		assert.Equalf(t, tt.wantSubject, principal.Subject, "%s: want %v, got %v", tt.name, tt.wantSubject, principal.Subject)
+
+		assert.Equalf(t, "Bearer", principal.Scheme, "%s: want %v, got %v", tt.name, "Bearer", principal.Scheme)
+	}
+
+	lenient := JWT(JWTConfig{
+		Keys:               set,
+		AllowMissingExpiry: true,
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	r.Header.Set("Authorization", "Bearer "+keys.sign("HS256", "hs", valid(map[string]any{"exp": nil})))
+
+	principal, err := lenient.Authenticate(r)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	assert.Equalf(t, "user-1", principal.Subject, "want %v, got %v", "user-1", principal.Subject)
+
+	challengeTests := []struct {
+		err  error
+		want string
+	}{
+		{err: ErrNoCredentials, want: `Bearer realm="api"`},
+		{err: ErrTokenExpired, want: `Bearer realm="api", error="invalid_token"`},
+	}
+
+	for _, tt := range challengeTests {
+		got := authenticator.Challenge(tt.err)
+
+		assert.Equalf(t, tt.want, got, "want %v, got %v", tt.want, got)
+	}
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "should reject invalid json", data: `{`},
+		{name: "should reject empty oct keys", data: `{"keys":[{"kty":"oct","k":""}]}`},
+		{name: "should reject unsupported curves", data: `{"keys":[{"kty":"EC","crv":"P-384","x":"AA","y":"AA"}]}`},
+		{name: "should reject points off the curve", data: `{"keys":[{"kty":"EC","crv":"P-256","x":"` + base64.RawURLEncoding.EncodeToString(make([]byte, 32)) + `","y":"` + base64.RawURLEncoding.EncodeToString(make([]byte, 32)) + `"}]}`},
+		{name: "should reject short ed25519 keys", data: `{"keys":[{"kty":"OKP","crv":"Ed25519","x":"AAAA"}]}`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseJWKS([]byte(tt.data))
+
+		assert.Errorf(t, err, "%s: want error", tt.name)
+	}
+}
@@ -0,0 +1,338 @@
+package bind
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/codec"
+	"github.com/aakash-rajur/http/internal/convert"
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"github.com/aakash-rajur/http/params"
+	"github.com/aakash-rajur/http/validate"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+func Bind(r *http.Request, dst any) error {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind: target must be a non-nil pointer to struct, got %T", dst)
+	}
+
+	err := decodeStructBody(r, v.Elem())
+
+	if err != nil {
+		return err
+	}
+
+	s := newSources(r)
+
+	errs := bindStruct(s, v.Elem())
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return validate.Struct(dst)
+}
+
+func Body(r *http.Request, dst any) error {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("bind: target must be a non-nil pointer, got %T", dst)
+	}
+
+	return decodeBody(r, dst)
+}
+
+func decodeStructBody(r *http.Request, v reflect.Value) error {
+	// decode into a shadow so that clients cannot set fields owned by path, query, header or cookie
+	shadow := reflect.New(v.Type())
+
+	shadow.Elem().Set(v)
+
+	clearSourced(shadow.Elem())
+
+	err := decodeBody(r, shadow.Interface())
+
+	if err != nil {
+		return err
+	}
+
+	copyUnsourced(v, shadow.Elem())
+
+	return nil
+}
+
+func clearSourced(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i += 1 {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			clearSourced(v.Field(i))
+
+			continue
+		}
+
+		if sourced(field) {
+			v.Field(i).Set(reflect.Zero(field.Type))
+		}
+	}
+}
+
+func copyUnsourced(dst, src reflect.Value) {
+	t := dst.Type()
+
+	for i := 0; i < t.NumField(); i += 1 {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			copyUnsourced(dst.Field(i), src.Field(i))
+
+			continue
+		}
+
+		if !sourced(field) {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
+
+func sourced(field reflect.StructField) bool {
+	for _, source := range []string{"path", "query", "header", "cookie"} {
+		key, ok := field.Tag.Lookup(source)
+
+		if ok && key != "-" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeBody(r *http.Request, dst any) error {
+	if !hasBody(r) {
+		return nil
+	}
+
+	mediaType := negotiate.MediaType(r.Header.Get("Content-Type"))
+
+	if mediaType == "" || mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+		return nil
+	}
+
+	c, ok := codec.Lookup(mediaType)
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+	}
+
+	err := c.Decode(r.Body, dst)
+
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	// read failures such as body limits carry their own status and must not turn into a 400
+	var coded interface{ Status() int }
+
+	var tooLarge *http.MaxBytesError
+
+	if errors.As(err, &coded) || errors.As(err, &tooLarge) {
+		return err
+	}
+
+	return validate.Errors{
+		{Field: "body", Source: "body", Rule: "decode", Message: err.Error()},
+	}
+}
+
+func bindStruct(s *sources, v reflect.Value) validate.Errors {
+	errs := make(validate.Errors, 0)
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i += 1 {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, bindStruct(s, fv)...)
+
+			continue
+		}
+
+		source, key, values, found := s.lookup(field)
+
+		if !found {
+			fallback, ok := field.Tag.Lookup("default")
+
+			if !ok || !fv.IsZero() {
+				continue
+			}
+
+			source, values = "default", []string{fallback}
+		}
+
+		err := s.set(fv, source, key, values)
+
+		if err == nil {
+			continue
+		}
+
+		errs = append(
+			errs,
+			validate.FieldError{
+				Field:   validate.FieldName(field),
+				Source:  source,
+				Rule:    "type",
+				Message: err.Error(),
+			},
+		)
+	}
+
+	return errs
+}
+
+type sources struct {
+	r      *http.Request
+	params params.Params
+	form   *multipart.Form
+}
+
+func newSources(r *http.Request) *sources {
+	p, _ := params.FromRequest(r)
+
+	s := &sources{
+		r:      r,
+		params: p,
+	}
+
+	mediaType := negotiate.MediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "multipart/form-data":
+		if r.ParseMultipartForm(MaxMemory) == nil {
+			s.form = r.MultipartForm
+		}
+	case "application/x-www-form-urlencoded":
+		_ = r.ParseForm()
+	}
+
+	return s
+}
+
+func (s *sources) lookup(field reflect.StructField) (string, string, []string, bool) {
+	for _, source := range order {
+		key, ok := field.Tag.Lookup(source)
+
+		if !ok || key == "-" {
+			continue
+		}
+
+		values, found := s.values(source, key)
+
+		if found {
+			return source, key, values, true
+		}
+	}
+
+	return "", "", nil, false
+}
+
+func (s *sources) values(source, key string) ([]string, bool) {
+	switch source {
+	case "path":
+		value, ok := s.params[key]
+
+		return []string{value}, ok
+	case "query":
+		values, ok := s.r.URL.Query()[key]
+
+		return values, ok
+	case "header":
+		values := s.r.Header.Values(key)
+
+		return values, len(values) > 0
+	case "cookie":
+		cookie, err := s.r.Cookie(key)
+
+		if err != nil {
+			return nil, false
+		}
+
+		return []string{cookie.Value}, true
+	case "form":
+		if s.form != nil {
+			values, ok := s.form.Value[key]
+
+			return values, ok
+		}
+
+		values, ok := s.r.PostForm[key]
+
+		return values, ok
+	case "file":
+		if s.form == nil {
+			return nil, false
+		}
+
+		_, ok := s.form.File[key]
+
+		return []string{key}, ok
+	default:
+		return nil, false
+	}
+}
+
+func (s *sources) set(v reflect.Value, source, key string, values []string) error {
+	if source != "file" {
+		return convert.SetAll(v, values)
+	}
+
+	files := s.form.File[key]
+
+	switch v.Type() {
+	case fileHeaderType:
+		v.Set(reflect.ValueOf(files[0]))
+	case fileHeadersType:
+		v.Set(reflect.ValueOf(files))
+	default:
+		return fmt.Errorf("cannot bind file to %s", v.Type())
+	}
+
+	return nil
+}
+
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+var order = []string{"path", "query", "header", "cookie", "form", "file"}
+
+var (
+	fileHeaderType  = reflect.TypeOf(&multipart.FileHeader{})
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader{})
+)
+
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+const MaxMemory = 32 << 20
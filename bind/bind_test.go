@@ -0,0 +1,278 @@
+package bind
+
+import (
+	"bytes"
+	"github.com/aakash-rajur/http/params"
+	"github.com/aakash-rajur/http/validate"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type createBook struct {
+	UserId  int      `path:"userId" validate:"required,min=1"`
+	DryRun  bool     `query:"dryRun"`
+	Tags    []string `query:"tag"`
+	Token   string   `header:"X-Token" validate:"required"`
+	Session string   `cookie:"session"`
+	Limit   int      `query:"limit" default:"20" validate:"max=100"`
+	Name    string   `json:"name" xml:"name" form:"name" validate:"required,min=2"`
+	Format  string   `json:"format" xml:"format" form:"format" validate:"omitempty,enum=hardcover|paperback"`
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		target      string
+		wantErr     error
+		want        createBook
+	}{
+		{
+			name:        "should bind json body with every source",
+			contentType: "application/json",
+			body:        `{"name": "Alchemist", "format": "paperback"}`,
+			target:      "/users/10/books?dryRun=true&tag=a&tag=b",
+			want: createBook{
+				UserId:  10,
+				DryRun:  true,
+				Tags:    []string{"a", "b"},
+				Token:   "secret",
+				Session: "abc",
+				Limit:   20,
+				Name:    "Alchemist",
+				Format:  "paperback",
+			},
+		},
+		{
+			name:        "should bind xml body",
+			contentType: "application/xml; charset=utf-8",
+			body:        `<createBook><name>Alchemist</name><format>hardcover</format></createBook>`,
+			target:      "/users/10/books?limit=50",
+			want: createBook{
+				UserId:  10,
+				Token:   "secret",
+				Session: "abc",
+				Limit:   50,
+				Name:    "Alchemist",
+				Format:  "hardcover",
+			},
+		},
+		{
+			name:        "should bind cbor body through the codec registry",
+			contentType: "application/cbor",
+			body:        "\xa2\x64name\x69Alchemist\x66format\x69paperback",
+			target:      "/users/10/books",
+			want: createBook{
+				UserId:  10,
+				Token:   "secret",
+				Session: "abc",
+				Limit:   20,
+				Name:    "Alchemist",
+				Format:  "paperback",
+			},
+		},
+		{
+			name:        "should bind url encoded form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"name": {"Alchemist"}, "format": {"paperback"}}.Encode(),
+			target:      "/users/10/books",
+			want: createBook{
+				UserId:  10,
+				Token:   "secret",
+				Session: "abc",
+				Limit:   20,
+				Name:    "Alchemist",
+				Format:  "paperback",
+			},
+		},
+		{
+			name:        "should aggregate conversion errors",
+			contentType: "application/json",
+			body:        `{"name": "Alchemist"}`,
+			target:      "/users/10/books?limit=many&dryRun=maybe",
+			wantErr: validate.Errors{
+				{Field: "dryRun", Source: "query", Rule: "type", Message: `cannot convert "maybe" to bool: invalid syntax`},
+				{Field: "limit", Source: "query", Rule: "type", Message: `cannot convert "many" to int: invalid syntax`},
+			},
+		},
+		{
+			name:        "should aggregate validation errors",
+			contentType: "application/json",
+			body:        `{"name": "A", "format": "ebook"}`,
+			target:      "/users/10/books?limit=500",
+			wantErr: validate.Errors{
+				{Field: "limit", Rule: "max", Param: "100", Message: "must be at most 100"},
+				{Field: "name", Rule: "min", Param: "2", Message: "must be at least 2"},
+				{Field: "format", Rule: "enum", Param: "hardcover|paperback", Message: "must be one of hardcover, paperback"},
+			},
+		},
+		{
+			name:        "should report malformed body",
+			contentType: "application/json",
+			body:        `{"name": `,
+			target:      "/users/10/books",
+			wantErr: validate.Errors{
+				{Field: "body", Source: "body", Rule: "decode", Message: "unexpected EOF"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRequest(tt.target, tt.contentType, strings.NewReader(tt.body))
+
+			got := createBook{}
+
+			err := Bind(r, &got)
+
+			if tt.wantErr != nil {
+				assert.Equalf(t, tt.wantErr, err, "Bind() err = %v, want %v", err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoErrorf(t, err, "Bind() err = %v", err)
+
+			assert.Equalf(t, tt.want, got, "Bind() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestBody(t *testing.T) {
+	t.Parallel()
+
+	r := newRequest("/tags", "application/json", strings.NewReader(`["a","b"]`))
+
+	var got []string
+
+	err := Body(r, &got)
+
+	assert.NoErrorf(t, err, "Body() err = %v", err)
+
+	assert.Equalf(t, []string{"a", "b"}, got, "Body() = %v", got)
+
+	err = Body(r, got)
+
+	assert.Errorf(t, err, "Body() should reject non pointer targets")
+}
+
+func TestBind_MassAssignment(t *testing.T) {
+	t.Parallel()
+
+	type account struct {
+		Role    string `header:"X-Role"`
+		Owner   string `path:"userId"`
+		Preset  string `query:"preset"`
+		Display string `json:"display"`
+	}
+
+	tests := []struct {
+		name   string
+		role   string
+		target string
+		want   account
+	}{
+		{
+			name:   "should ignore body values for sourced fields",
+			target: "/accounts",
+			want:   account{Owner: "10", Preset: "kept", Display: "Alice"},
+		},
+		{
+			name:   "should prefer the declared source",
+			role:   "viewer",
+			target: "/accounts?preset=query",
+			want:   account{Role: "viewer", Owner: "10", Preset: "query", Display: "Alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"Role":"admin","Owner":"1","Preset":"body","display":"Alice"}`
+
+			r := newRequest(tt.target, "application/json", strings.NewReader(body))
+
+			if tt.role != "" {
+				r.Header.Set("X-Role", tt.role)
+			}
+
+			got := account{Preset: "kept"}
+
+			err := Bind(r, &got)
+
+			assert.NoErrorf(t, err, "Bind() err = %v", err)
+
+			assert.Equalf(t, tt.want, got, "Bind() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestBind_UnsupportedMediaType(t *testing.T) {
+	t.Parallel()
+
+	r := newRequest("/users/10/books", "text/csv", strings.NewReader("name\nAlchemist"))
+
+	err := Bind(r, &createBook{})
+
+	assert.ErrorIsf(t, err, ErrUnsupportedMediaType, "Bind() err = %v", err)
+}
+
+func TestBind_Multipart(t *testing.T) {
+	t.Parallel()
+
+	type upload struct {
+		Name    string                  `form:"name" validate:"required"`
+		Cover   *multipart.FileHeader   `file:"cover"`
+		Samples []*multipart.FileHeader `file:"sample"`
+	}
+
+	body := new(bytes.Buffer)
+
+	mw := multipart.NewWriter(body)
+
+	_ = mw.WriteField("name", "Alchemist")
+
+	for _, name := range []string{"cover", "sample", "sample"} {
+		fw, _ := mw.CreateFormFile(name, name+".png")
+
+		_, _ = fw.Write([]byte("png"))
+	}
+
+	_ = mw.Close()
+
+	r := newRequest("/users/10/books", mw.FormDataContentType(), body)
+
+	got := upload{}
+
+	err := Bind(r, &got)
+
+	assert.NoErrorf(t, err, "Bind() err = %v", err)
+
+	assert.Equalf(t, "Alchemist", got.Name, "Bind() name = %v", got.Name)
+
+	assert.Equalf(t, "cover.png", got.Cover.Filename, "Bind() cover = %v", got.Cover)
+
+	assert.Lenf(t, got.Samples, 2, "Bind() samples = %v", got.Samples)
+}
+
+func newRequest(target, contentType string, body io.Reader) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, target, body)
+
+	r.Header.Set("Content-Type", contentType)
+
+	r.Header.Set("X-Token", "secret")
+
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	p := params.Params{"userId": "10"}
+
+	return r.WithContext(p.WithinContext(r.Context()))
+}
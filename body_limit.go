@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func BodyLimit(config BodyLimitConfig) Middleware {
+	cfg := saneBodyLimitConfig(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		limit := cfg.Limit
+
+		override, ok := routeValue[int64](r, bodyLimitKey)
+
+		if ok {
+			limit = override
+		}
+
+		if limit <= 0 || r.Body == nil || r.Body == http.NoBody {
+			next(r)
+
+			return
+		}
+
+		if r.ContentLength > limit {
+			HandleError(w, r, &BodyTooLargeError{Limit: limit})
+
+			return
+		}
+
+		body := &limitedBody{
+			ReadCloser: http.MaxBytesReader(w, r.Body, limit),
+			limit:      limit,
+		}
+
+		lr := r.WithContext(context.WithValue(r.Context(), effectiveBodyLimitKey, limit))
+
+		lr.Body = body
+
+		next(lr)
+
+		if body.err == nil {
+			return
+		}
+
+		rw, ok := UnwrapResponseWriter(w)
+
+		if ok && rw.StatusCode == 0 {
+			HandleError(w, lr, body.err)
+		}
+	}
+}
+
+func WithBodyLimit(limit int64) RouteOption {
+	return WithMetadata(bodyLimitKey, limit)
+}
+
+func effectiveBodyLimit(r *http.Request) (int64, bool) {
+	limit, ok := r.Context().Value(effectiveBodyLimitKey).(int64)
+
+	if ok {
+		return limit, true
+	}
+
+	return routeValue[int64](r, bodyLimitKey)
+}
+
+func saneBodyLimitConfig(in BodyLimitConfig) BodyLimitConfig {
+	out := BodyLimitConfig{
+		Limit: 1 << 20,
+	}
+
+	if in.Limit != 0 {
+		out.Limit = in.Limit
+	}
+
+	return out
+}
+
+type BodyLimitConfig struct {
+	Limit int64
+}
+
+type BodyTooLargeError struct {
+	Limit int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("request body larger than %d bytes", e.Limit)
+}
+
+func (e *BodyTooLargeError) Unwrap() error {
+	return &http.MaxBytesError{Limit: e.Limit}
+}
+
+func (e *BodyTooLargeError) Status() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	err   error
+}
+
+func (body *limitedBody) Read(buffer []byte) (int, error) {
+	n, err := body.ReadCloser.Read(buffer)
+
+	var maxBytes *http.MaxBytesError
+
+	if errors.As(err, &maxBytes) {
+		body.err = &BodyTooLargeError{Limit: body.limit}
+
+		return n, body.err
+	}
+
+	return n, err
+}
+
+const (
+	bodyLimitKey          = "http_body_limit"
+	effectiveBodyLimitKey = "http_effective_body_limit"
+)
@@ -0,0 +1,303 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/bind"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		config     BodyLimitConfig
+		target     string
+		body       string
+		streamed   bool
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should pass bodies within the limit",
+			config:     BodyLimitConfig{Limit: 16},
+			target:     "/echo",
+			body:       "name=Alchemist",
+			wantStatus: http.StatusOK,
+			wantBody:   "name=Alchemist",
+		},
+		{
+			name:       "should reject early on content length",
+			config:     BodyLimitConfig{Limit: 8},
+			target:     "/echo",
+			body:       "name=Alchemist",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"detail":"request body larger than 8 bytes","instance":"/echo","status":413,"title":"Request Entity Too Large"}`,
+		},
+		{
+			name:       "should enforce the limit while streaming",
+			config:     BodyLimitConfig{Limit: 8},
+			target:     "/echo",
+			body:       "name=Alchemist",
+			streamed:   true,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"detail":"request body larger than 8 bytes","instance":"/echo","status":413,"title":"Request Entity Too Large"}`,
+		},
+		{
+			name:       "should respond 413 when the handler ignores the limit",
+			config:     BodyLimitConfig{Limit: 8},
+			target:     "/drain",
+			body:       "name=Alchemist",
+			streamed:   true,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"detail":"request body larger than 8 bytes","instance":"/drain","status":413,"title":"Request Entity Too Large"}`,
+		},
+		{
+			name:       "should expose a typed error to handlers",
+			config:     BodyLimitConfig{Limit: 8},
+			target:     "/typed",
+			body:       "name=Alchemist",
+			streamed:   true,
+			wantStatus: http.StatusOK,
+			wantBody:   "8|8",
+		},
+		{
+			name:       "should apply route overrides",
+			config:     BodyLimitConfig{Limit: 8},
+			target:     "/upload",
+			body:       "name=Alchemist",
+			wantStatus: http.StatusOK,
+			wantBody:   "name=Alchemist",
+		},
+		{
+			name:       "should disable limits with a negative default",
+			config:     BodyLimitConfig{Limit: -1},
+			target:     "/echo",
+			body:       strings.Repeat("a", 2<<20),
+			streamed:   true,
+			wantStatus: http.StatusOK,
+			wantBody:   strings.Repeat("a", 2<<20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+
+			router.Use(BodyLimit(tt.config))
+
+			router.PostE("/echo", func(w http.ResponseWriter, r *http.Request) error {
+				body, err := io.ReadAll(r.Body)
+
+				if err != nil {
+					return err
+				}
+
+				_, err = w.Write(body)
+
+				return err
+			})
+
+			router.PostFunc("/drain", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+			})
+
+			router.PostFunc("/typed", func(w http.ResponseWriter, r *http.Request) {
+				_, err := io.ReadAll(r.Body)
+
+				var tooLarge *BodyTooLargeError
+
+				var maxBytes *http.MaxBytesError
+
+				if errors.As(err, &tooLarge) && errors.As(err, &maxBytes) {
+					_, _ = fmt.Fprintf(w, "%d|%d", tooLarge.Limit, maxBytes.Limit)
+				}
+			})
+
+			router.PostFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				_, _ = w.Write(body)
+			}, WithBodyLimit(1024))
+
+			var body io.Reader = strings.NewReader(tt.body)
+
+			if tt.streamed {
+				body = io.MultiReader(body)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, tt.target, body)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if strings.HasPrefix(tt.wantBody, "{") {
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			} else {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBodyLimit_Decompressed(t *testing.T) {
+	t.Parallel()
+
+	tooLarge := `{"detail":"request body larger than 1024 bytes","instance":"/upload","status":413,"title":"Request Entity Too Large"}`
+
+	tests := []struct {
+		name       string
+		decodeLast bool
+		config     BodyLimitConfig
+		opts       []RouteOption
+		body       []byte
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should limit decoded bytes when decompressing first",
+			opts:       []RouteOption{WithBodyLimit(1024)},
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 4096)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   tooLarge,
+		},
+		{
+			name:       "should limit decoded bytes when decompressing last",
+			decodeLast: true,
+			opts:       []RouteOption{WithBodyLimit(1024)},
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 4096)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   tooLarge,
+		},
+		{
+			name:       "should pass decoded bodies within the limit",
+			decodeLast: true,
+			opts:       []RouteOption{WithBodyLimit(1024)},
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 1024)),
+			wantStatus: http.StatusOK,
+			wantBody:   "1024",
+		},
+		{
+			name:       "should apply the global limit to decoded bytes when decompressing first",
+			config:     BodyLimitConfig{Limit: 1024},
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 4096)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   tooLarge,
+		},
+		{
+			name:       "should apply the global limit to decoded bytes when decompressing last",
+			decodeLast: true,
+			config:     BodyLimitConfig{Limit: 1024},
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 4096)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   tooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+
+			if tt.decodeLast {
+				router.Use(BodyLimit(tt.config))
+
+				router.Use(Decompress(DecompressConfig{}))
+			} else {
+				router.Use(Decompress(DecompressConfig{}))
+
+				router.Use(BodyLimit(tt.config))
+			}
+
+			router.PostE("/upload", func(w http.ResponseWriter, r *http.Request) error {
+				body, err := io.ReadAll(r.Body)
+
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintf(w, "%d", len(body))
+
+				return err
+			}, tt.opts...)
+
+			r := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewReader(tt.body))
+
+			r.Header.Set("Content-Encoding", "gzip")
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if strings.HasPrefix(tt.wantBody, "{") {
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			} else {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBodyLimit_Bind(t *testing.T) {
+	t.Parallel()
+
+	type book struct {
+		Title string `json:"title"`
+	}
+
+	router := NewRouter()
+
+	router.Use(BodyLimit(BodyLimitConfig{Limit: 16}))
+
+	router.PostE("/bind", func(w http.ResponseWriter, r *http.Request) error {
+		var req book
+
+		return bind.Bind(r, &req)
+	})
+
+	router.Post("/typed", Typed(func(_ context.Context, req book) (book, error) {
+		return req, nil
+	}))
+
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "should respond 413 when bind exceeds the limit",
+			target: "/bind",
+		},
+		{
+			name:   "should respond 413 when a typed handler exceeds the limit",
+			target: "/typed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := io.MultiReader(strings.NewReader(`{"title":"The Alchemist"}`))
+
+			r := httptest.NewRequest(http.MethodPost, tt.target, body)
+
+			r.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, http.StatusRequestEntityTooLarge, w.Code, "want %v, got %v", http.StatusRequestEntityTooLarge, w.Code)
+		})
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"crypto"
 	"crypto/sha256"
 	"crypto/tls"
-	"encoding/json"
 	"fmt"
 	h "github.com/aakash-rajur/http"
 	"github.com/aakash-rajur/http/params"
+	"github.com/aakash-rajur/http/problem"
+	"github.com/aakash-rajur/http/render"
+	"github.com/aakash-rajur/http/versioning"
 	"io"
 	"net/http"
-	"strconv"
 	"time"
 )
 
@@ -25,179 +26,40 @@ func main() {
 
 	router.Use(h.Logger(h.LoggerConfig{}))
 
-	router.GetFunc(
-		"/",
-		func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
+	router.Use(h.RequestID(h.RequestIDConfig{}))
 
-			_, _ = w.Write([]byte("Hello World!"))
-		},
-	)
-
-	router.GetFunc(
-		"/health",
-		func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
+	router.Use(h.Recover(h.RecoverConfig{}))
 
-			_, _ = w.Write([]byte("OK"))
-		},
-	)
+	router.Use(h.Timeout(h.TimeoutConfig{Duration: 30 * time.Second}))
 
-	router.GetFunc(
-		"/api/v2/books",
-		func(w http.ResponseWriter, r *http.Request) {
-			buffer, err := json.Marshal(books)
+	router.Use(h.Compress(h.CompressConfig{}))
 
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+	router.Use(h.Decompress(h.DecompressConfig{}))
 
-			w.Header().Set("Content-Type", "application/json")
+	router.Use(h.BodyLimit(h.BodyLimitConfig{Limit: 1 << 20}))
 
-			w.WriteHeader(http.StatusOK)
+	api := versioning.New(router, versioning.Config{Prefix: "/api", Vendor: "aakash-rajur"})
 
-			_, _ = w.Write(buffer)
-		},
-	)
+	v2 := api.Version(versioning.Version{Name: "v2"})
 
 	router.GetFunc(
-		"/api/v2/books/{id}",
-		func(w http.ResponseWriter, r *http.Request) {
-			p, ok := params.FromRequest(r)
-
-			if !ok {
-				http.Error(w, "unable to parse param", http.StatusInternalServerError)
-
-				return
-			}
-
-			idString := p.Get("id", "")
-
-			id, err := strconv.Atoi(idString)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			book := books[id-1]
-
-			buffer, err := json.Marshal(book)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-
-			w.WriteHeader(http.StatusOK)
-
-			_, _ = w.Write(buffer)
-		},
-	)
-
-	router.GetFunc(
-		"/api/v2/users",
+		"/",
 		func(w http.ResponseWriter, r *http.Request) {
-			buffer, err := json.Marshal(users)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-
-			w.WriteHeader(http.StatusOK)
-
-			_, _ = w.Write(buffer)
+			_ = render.Text(w, http.StatusOK, "Hello World!")
 		},
 	)
 
 	router.GetFunc(
-		"/api/v2/users/{id}",
+		"/health",
 		func(w http.ResponseWriter, r *http.Request) {
-			p, ok := params.FromRequest(r)
-
-			if !ok {
-				http.Error(w, "unable to parse param", http.StatusInternalServerError)
-
-				return
-			}
-
-			idString := p.Get("id", "")
-
-			id, err := strconv.Atoi(idString)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			user := users[id-1]
-
-			buffer, err := json.Marshal(user)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-
-			w.WriteHeader(http.StatusOK)
-
-			_, _ = w.Write(buffer)
+			_ = render.Text(w, http.StatusOK, "OK")
 		},
 	)
 
-	router.GetFunc(
-		"/api/v2/users/{id}/books",
-		func(w http.ResponseWriter, r *http.Request) {
-			p, ok := params.FromRequest(r)
-
-			if !ok {
-				http.Error(w, "unable to parse param", http.StatusInternalServerError)
-
-				return
-			}
-
-			idString := p.Get("id", "")
-
-			id, err := strconv.Atoi(idString)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			user := users[id-1]
-
-			payload := map[string]interface{}{
-				"user":  user,
-				"books": books,
-			}
-
-			buffer, err := json.Marshal(payload)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+	v2.Resource("/books", bookController{})
 
-				return
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-
-			w.WriteHeader(http.StatusOK)
-
-			_, _ = w.Write(buffer)
-		},
-	)
+	v2.Resource("/users", userController{}, h.WithResourceParam("userId")).
+		Resource("books", userBookController{})
 
 	router.GetFunc(
 		"/identity",
@@ -226,16 +88,14 @@ func main() {
 			p, ok := params.FromRequest(r)
 
 			if !ok {
-				http.Error(w, "unable to parse param", http.StatusInternalServerError)
+				h.RenderError(w, r, problem.New(http.StatusInternalServerError, "unable to parse param"))
 
 				return
 			}
 
 			id := p.Get("id", "")
 
-			w.WriteHeader(http.StatusOK)
-
-			_, _ = w.Write([]byte(id))
+			_ = render.Text(w, http.StatusOK, id)
 		},
 	)
 
@@ -248,86 +108,51 @@ func main() {
 	router.GetFunc(
 		"/public",
 		func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-
-			w.WriteHeader(http.StatusOK)
-
 			payload := map[string]interface{}{
 				"modulusLength": modulusLength,
 				"hash":          hash.String(),
 				"publicKey":     keyPair.PublicKeyBytes,
 			}
 
-			jsonPayload, err := json.Marshal(payload)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			_, _ = w.Write(jsonPayload)
+			_ = render.Negotiate(w, r, http.StatusOK, payload)
 		},
 	)
 
-	router.PostFunc(
+	router.PostE(
 		"/private",
-		func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-
+		func(w http.ResponseWriter, r *http.Request) error {
 			buffer, err := io.ReadAll(r.Body)
 
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
+				return err
 			}
 
-			_ = buffer
-
 			decrypted, err := keyPair.Decrypt(buffer)
 
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
+				return h.Errorf(http.StatusBadRequest, "unable to decrypt payload: %w", err)
 			}
 
 			payload := map[string]interface{}{
 				"message": string(decrypted),
 			}
 
-			jsonPayload, err := json.Marshal(payload)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-
-			_, _ = w.Write(jsonPayload)
+			return render.Negotiate(w, r, http.StatusOK, payload)
 		},
+		h.WithBodyLimit(64<<10),
 	)
 
-	router.GetFunc(
+	router.GetE(
 		"/settings.json",
-		func(w http.ResponseWriter, r *http.Request) {
+		func(w http.ResponseWriter, r *http.Request) error {
 			keyPair, err := generateRSAKeyPair(hash, modulusLength)
 
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
+				return err
 			}
 
 			// send the public key to the client along with existing settings object
 
-			w.Header().Set("Content-Type", "application/json")
-
-			w.WriteHeader(http.StatusOK)
-
 			payload := map[string]interface{}{
 				"modulusLength": modulusLength,
 				"hash":          hash.String(),
@@ -335,17 +160,9 @@ func main() {
 				"other":         "stuff",
 			}
 
-			jsonPayload, err := json.Marshal(payload)
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-
-				return
-			}
-
-			_, _ = w.Write(jsonPayload)
-
 			// store the keypair somewhere for setup-session to pickup
+
+			return render.JSON(w, http.StatusOK, payload)
 		},
 	)
 
@@ -0,0 +1,75 @@
+package main
+
+import (
+	h "github.com/aakash-rajur/http"
+	"github.com/aakash-rajur/http/params"
+	"github.com/aakash-rajur/http/render"
+	"net/http"
+)
+
+type bookController struct{}
+
+func (bookController) Index(w http.ResponseWriter, r *http.Request) error {
+	return render.Negotiate(w, r, http.StatusOK, books)
+}
+
+func (bookController) Show(w http.ResponseWriter, r *http.Request) error {
+	id, err := params.Int(r, "id")
+
+	if err != nil {
+		return err
+	}
+
+	if id < 1 || id > len(books) {
+		return h.Errorf(http.StatusNotFound, "book %d not found", id)
+	}
+
+	return render.Negotiate(w, r, http.StatusOK, books[id-1])
+}
+
+type userController struct{}
+
+func (userController) Index(w http.ResponseWriter, r *http.Request) error {
+	return render.Negotiate(w, r, http.StatusOK, users)
+}
+
+func (userController) Show(w http.ResponseWriter, r *http.Request) error {
+	user, err := userFromRequest(r)
+
+	if err != nil {
+		return err
+	}
+
+	return render.Negotiate(w, r, http.StatusOK, user)
+}
+
+type userBookController struct{}
+
+func (userBookController) Index(w http.ResponseWriter, r *http.Request) error {
+	user, err := userFromRequest(r)
+
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"user":  user,
+		"books": books,
+	}
+
+	return render.Negotiate(w, r, http.StatusOK, payload)
+}
+
+func userFromRequest(r *http.Request) (User, error) {
+	id, err := params.Int(r, "userId")
+
+	if err != nil {
+		return User{}, err
+	}
+
+	if id < 1 || id > len(users) {
+		return User{}, h.Errorf(http.StatusNotFound, "user %d not found", id)
+	}
+
+	return users[id-1], nil
+}
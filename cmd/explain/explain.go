@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	h "github.com/aakash-rajur/http"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func main() {
+	routesFile := flag.String("routes", "", "route dump, one \"METHOD /pattern\" per line")
+
+	dialect := flag.String("dialect", "both", "pattern dialect: braces, colon or both")
+
+	flag.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "usage: explain -routes routes.txt [-dialect both] METHOD PATH")
+
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *routesFile == "" || flag.NArg() != 2 {
+		flag.Usage()
+
+		os.Exit(2)
+	}
+
+	router := h.NewRouter()
+
+	router.Dialect(dialectFromName(*dialect))
+
+	err := loadRoutes(router, *routesFile)
+
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+
+	r, err := http.NewRequest(strings.ToUpper(flag.Arg(0)), flag.Arg(1), nil)
+
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+
+	fmt.Println(router.Explain(r))
+}
+
+func loadRoutes(router *h.Router, name string) error {
+	file, err := os.Open(name)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		router.Handle(line, http.NotFoundHandler())
+	}
+
+	return scanner.Err()
+}
+
+func dialectFromName(name string) h.Dialect {
+	switch strings.ToLower(name) {
+	case "braces":
+		return h.DialectBraces
+	case "colon":
+		return h.DialectColon
+	default:
+		return h.DialectBoth
+	}
+}
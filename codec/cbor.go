@@ -0,0 +1,542 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrCBOR = errors.New("codec: malformed cbor")
+
+const (
+	cborUnsigned byte = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	cborIndefinite byte = 31
+	cborBreak      byte = 0xff
+)
+
+type CBOR struct{}
+
+func (CBOR) MediaType() string {
+	return "application/cbor"
+}
+
+func (CBOR) Encode(w io.Writer, v any) error {
+	return encode(w, &cborEncoder{}, v)
+}
+
+func (CBOR) Decode(r io.Reader, v any) error {
+	return decode(r, v, "cbor", parseCBOR)
+}
+
+type cborEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *cborEncoder) head(major byte, n uint64) {
+	major <<= 5
+
+	switch {
+	case n < 24:
+		e.buf.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		e.buf.Write([]byte{major | 24, byte(n)})
+	case n <= math.MaxUint16:
+		e.buf.WriteByte(major | 25)
+
+		e.buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	case n <= math.MaxUint32:
+		e.buf.WriteByte(major | 26)
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	default:
+		e.buf.WriteByte(major | 27)
+
+		e.buf.Write(binary.BigEndian.AppendUint64(nil, n))
+	}
+}
+
+func (e *cborEncoder) writeNil() {
+	e.buf.WriteByte(0xf6)
+}
+
+func (e *cborEncoder) writeBool(b bool) {
+	if b {
+		e.buf.WriteByte(0xf5)
+
+		return
+	}
+
+	e.buf.WriteByte(0xf4)
+}
+
+func (e *cborEncoder) writeInt(n int64) {
+	if n >= 0 {
+		e.head(cborUnsigned, uint64(n))
+
+		return
+	}
+
+	e.head(cborNegative, uint64(-1-n))
+}
+
+func (e *cborEncoder) writeUint(n uint64) {
+	e.head(cborUnsigned, n)
+}
+
+func (e *cborEncoder) writeFloat(f float64, _ int) {
+	if math.IsNaN(f) {
+		e.buf.Write([]byte{0xf9, 0x7e, 0x00})
+
+		return
+	}
+
+	single := float32(f)
+
+	if float64(single) != f {
+		e.buf.WriteByte(0xfb)
+
+		e.buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(f)))
+
+		return
+	}
+
+	half := toHalf(single)
+
+	if fromHalf(half) == f {
+		e.buf.WriteByte(0xf9)
+
+		e.buf.Write(binary.BigEndian.AppendUint16(nil, half))
+
+		return
+	}
+
+	e.buf.WriteByte(0xfa)
+
+	e.buf.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(single)))
+}
+
+func (e *cborEncoder) writeString(s string) {
+	e.head(cborText, uint64(len(s)))
+
+	e.buf.WriteString(s)
+}
+
+func (e *cborEncoder) writeBytes(b []byte) {
+	e.head(cborBytes, uint64(len(b)))
+
+	e.buf.Write(b)
+}
+
+func (e *cborEncoder) writeArray(n int) {
+	e.head(cborArray, uint64(n))
+}
+
+func (e *cborEncoder) writeMap(n int) {
+	e.head(cborMap, uint64(n))
+}
+
+func (e *cborEncoder) writeTime(t time.Time) {
+	e.head(cborTag, 0)
+
+	e.writeString(t.Format(time.RFC3339Nano))
+}
+
+func (e *cborEncoder) tag() string {
+	return "cbor"
+}
+
+func (e *cborEncoder) buffer() *bytes.Buffer {
+	return &e.buf
+}
+
+func (e *cborEncoder) fork() encoder {
+	return &cborEncoder{}
+}
+
+func toHalf(f float32) uint16 {
+	bits := math.Float32bits(f)
+
+	sign := uint16(bits>>16) & 0x8000
+
+	exponent := int((bits>>23)&0xff) - 127 + 15
+
+	mantissa := bits & 0x7fffff
+
+	switch {
+	case (bits>>23)&0xff == 0xff:
+		if mantissa != 0 {
+			return sign | 0x7e00
+		}
+
+		return sign | 0x7c00
+	case exponent >= 0x1f:
+		return sign | 0x7c00
+	case exponent <= 0:
+		if exponent < -10 {
+			return sign
+		}
+
+		mantissa |= 0x800000
+
+		return sign | uint16(mantissa>>uint(14-exponent))
+	default:
+		return sign | uint16(exponent)<<10 | uint16(mantissa>>13)
+	}
+}
+
+func fromHalf(h uint16) float64 {
+	exponent := int(h>>10) & 0x1f
+
+	mantissa := float64(h & 0x3ff)
+
+	var value float64
+
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		value = math.Inf(1)
+
+		if mantissa != 0 {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+
+	if h&0x8000 != 0 {
+		value = -value
+	}
+
+	return value
+}
+
+func parseCBOR(r *reader, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, ErrTooDeep
+	}
+
+	initial, err := r.next(1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	major, info := initial[0]>>5, initial[0]&0x1f
+
+	if major == cborSimple {
+		return parseCBORSimple(r, info)
+	}
+
+	if info == cborIndefinite {
+		return parseCBORIndefinite(r, major, depth)
+	}
+
+	n, err := cborArgument(r, info)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+
+		return int64(n), nil
+	case cborNegative:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: negative integer overflows int64", ErrCBOR)
+		}
+
+		return -1 - int64(n), nil
+	case cborBytes:
+		chunk, err := r.next(r.bounded(n))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte{}, chunk...), nil
+	case cborText:
+		chunk, err := r.next(r.bounded(n))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !utf8.Valid(chunk) {
+			return nil, fmt.Errorf("%w: invalid utf-8 in text string", ErrCBOR)
+		}
+
+		return string(chunk), nil
+	case cborArray:
+		items := make([]any, 0, r.capacity(n))
+
+		for i := uint64(0); i < n; i++ {
+			item, err := parseCBOR(r, depth+1)
+
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+	case cborMap:
+		pairs := make([]pair, 0, r.capacity(n))
+
+		for i := uint64(0); i < n; i++ {
+			each, err := parseCBORPair(r, depth)
+
+			if err != nil {
+				return nil, err
+			}
+
+			pairs = append(pairs, each)
+		}
+
+		return pairs, nil
+	default:
+		return parseCBORTag(r, n, depth)
+	}
+}
+
+func cborArgument(r *reader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		chunk, err := r.next(1)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(chunk[0]), nil
+	case info == 25:
+		chunk, err := r.next(2)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(binary.BigEndian.Uint16(chunk)), nil
+	case info == 26:
+		chunk, err := r.next(4)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(binary.BigEndian.Uint32(chunk)), nil
+	case info == 27:
+		chunk, err := r.next(8)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return binary.BigEndian.Uint64(chunk), nil
+	default:
+		return 0, fmt.Errorf("%w: reserved additional information %d", ErrCBOR, info)
+	}
+}
+
+func parseCBORPair(r *reader, depth int) (pair, error) {
+	key, err := parseCBOR(r, depth+1)
+
+	if err != nil {
+		return pair{}, err
+	}
+
+	value, err := parseCBOR(r, depth+1)
+
+	if err != nil {
+		return pair{}, err
+	}
+
+	return pair{key, value}, nil
+}
+
+func parseCBORIndefinite(r *reader, major byte, depth int) (any, error) {
+	switch major {
+	case cborBytes, cborText:
+		var buf bytes.Buffer
+
+		for {
+			next, err := r.peek()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if next == cborBreak {
+				r.pos++
+
+				break
+			}
+
+			if next>>5 != major || next&0x1f == cborIndefinite {
+				return nil, fmt.Errorf("%w: invalid chunk in indefinite string", ErrCBOR)
+			}
+
+			chunk, err := parseCBOR(r, depth+1)
+
+			if err != nil {
+				return nil, err
+			}
+
+			switch value := chunk.(type) {
+			case string:
+				buf.WriteString(value)
+			case []byte:
+				buf.Write(value)
+			}
+		}
+
+		if major == cborText {
+			return buf.String(), nil
+		}
+
+		return buf.Bytes(), nil
+	case cborArray:
+		items := make([]any, 0)
+
+		for {
+			next, err := r.peek()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if next == cborBreak {
+				r.pos++
+
+				return items, nil
+			}
+
+			item, err := parseCBOR(r, depth+1)
+
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+	case cborMap:
+		pairs := make([]pair, 0)
+
+		for {
+			next, err := r.peek()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if next == cborBreak {
+				r.pos++
+
+				return pairs, nil
+			}
+
+			each, err := parseCBORPair(r, depth)
+
+			if err != nil {
+				return nil, err
+			}
+
+			pairs = append(pairs, each)
+		}
+	default:
+		return nil, fmt.Errorf("%w: indefinite length for major type %d", ErrCBOR, major)
+	}
+}
+
+func parseCBORTag(r *reader, tag uint64, depth int) (any, error) {
+	content, err := parseCBOR(r, depth+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case 0:
+		text, ok := content.(string)
+
+		if !ok {
+			return nil, fmt.Errorf("%w: tag 0 requires a text string", ErrCBOR)
+		}
+
+		return time.Parse(time.RFC3339Nano, text)
+	case 1:
+		switch value := content.(type) {
+		case int64:
+			return time.Unix(value, 0).UTC(), nil
+		case uint64:
+			return time.Unix(int64(value), 0).UTC(), nil
+		case float64:
+			seconds, fraction := math.Modf(value)
+
+			return time.Unix(int64(seconds), int64(fraction*1e9)).UTC(), nil
+		default:
+			return nil, fmt.Errorf("%w: tag 1 requires a number", ErrCBOR)
+		}
+	default:
+		return content, nil
+	}
+}
+
+func parseCBORSimple(r *reader, info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		chunk, err := r.next(2)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return fromHalf(binary.BigEndian.Uint16(chunk)), nil
+	case 26:
+		chunk, err := r.next(4)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(chunk))), nil
+	case 27:
+		chunk, err := r.next(8)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(chunk)), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported simple value %d", ErrCBOR, info)
+	}
+}
@@ -0,0 +1,213 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCBOR_Encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "0", value: 0, expected: "00"},
+		{name: "23", value: 23, expected: "17"},
+		{name: "24", value: 24, expected: "1818"},
+		{name: "1000", value: 1000, expected: "1903e8"},
+		{name: "1000000", value: 1000000, expected: "1a000f4240"},
+		{name: "1000000000000", value: int64(1000000000000), expected: "1b000000e8d4a51000"},
+		{name: "max uint64", value: uint64(math.MaxUint64), expected: "1bffffffffffffffff"},
+		{name: "-1", value: -1, expected: "20"},
+		{name: "-1000", value: -1000, expected: "3903e7"},
+		{name: "0.0", value: 0.0, expected: "f90000"},
+		{name: "-0.0", value: math.Copysign(0, -1), expected: "f98000"},
+		{name: "1.0", value: 1.0, expected: "f93c00"},
+		{name: "1.1", value: 1.1, expected: "fb3ff199999999999a"},
+		{name: "1.5", value: 1.5, expected: "f93e00"},
+		{name: "65504.0", value: 65504.0, expected: "f97bff"},
+		{name: "100000.0", value: 100000.0, expected: "fa47c35000"},
+		{name: "3.4028234663852886e+38", value: 3.4028234663852886e+38, expected: "fa7f7fffff"},
+		{name: "1.0e+300", value: 1.0e+300, expected: "fb7e37e43c8800759c"},
+		{name: "5.960464477539063e-8", value: 5.960464477539063e-8, expected: "f90001"},
+		{name: "0.00006103515625", value: 0.00006103515625, expected: "f90400"},
+		{name: "-4.0", value: -4.0, expected: "f9c400"},
+		{name: "-4.1", value: -4.1, expected: "fbc010666666666666"},
+		{name: "infinity", value: math.Inf(1), expected: "f97c00"},
+		{name: "negative infinity", value: math.Inf(-1), expected: "f9fc00"},
+		{name: "nan", value: math.NaN(), expected: "f97e00"},
+		{name: "false", value: false, expected: "f4"},
+		{name: "true", value: true, expected: "f5"},
+		{name: "null", value: nil, expected: "f6"},
+		{name: "empty bytes", value: []byte{}, expected: "40"},
+		{name: "bytes", value: []byte{1, 2, 3, 4}, expected: "4401020304"},
+		{name: "empty string", value: "", expected: "60"},
+		{name: "a", value: "a", expected: "6161"},
+		{name: "IETF", value: "IETF", expected: "6449455446"},
+		{name: "unicode", value: "ü", expected: "62c3bc"},
+		{name: "empty array", value: []int{}, expected: "80"},
+		{name: "array", value: []int{1, 2, 3}, expected: "83010203"},
+		{name: "nested array", value: []any{1, []int{2, 3}, []int{4, 5}}, expected: "8301820203820405"},
+		{name: "empty map", value: map[string]int{}, expected: "a0"},
+		{name: "int map", value: map[int]int{3: 4, 1: 2}, expected: "a201020304"},
+		{name: "mixed map", value: map[string]any{"a": 1, "b": []int{2, 3}}, expected: "a26161016162820203"},
+		{
+			name:     "time",
+			value:    time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC),
+			expected: "c074323031332d30332d32315432303a30343a30305a",
+		},
+		{
+			name: "struct with tags",
+			value: struct {
+				Name  string `cbor:"n"`
+				Count int    `json:"count"`
+				Skip  string `cbor:"-"`
+				Empty string `cbor:",omitempty"`
+			}{Name: "x", Count: 1, Skip: "y"},
+			expected: "a2616e617865636f756e7401",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := CBOR{}.Encode(&buf, tc.value)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			actual := hex.EncodeToString(buf.Bytes())
+
+			assert.Equalf(t, tc.expected, actual, "want %v, got %v", tc.expected, actual)
+		})
+	}
+}
+
+func TestCBOR_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{name: "uint", input: "1903e8", expected: int64(1000)},
+		{name: "max uint64", input: "1bffffffffffffffff", expected: uint64(math.MaxUint64)},
+		{name: "negative", input: "3903e7", expected: int64(-1000)},
+		{name: "half", input: "f93e00", expected: 1.5},
+		{name: "half subnormal", input: "f90001", expected: 5.960464477539063e-8},
+		{name: "single", input: "fa47c35000", expected: 100000.0},
+		{name: "double", input: "fb3ff199999999999a", expected: 1.1},
+		{name: "undefined", input: "f7", expected: nil},
+		{name: "indefinite bytes", input: "5f42010243030405ff", expected: []byte{1, 2, 3, 4, 5}},
+		{name: "indefinite string", input: "7f657374726561646d696e67ff", expected: "streaming"},
+		{name: "indefinite array", input: "9f018202039f0405ffff", expected: []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}}},
+		{name: "indefinite map", input: "bf61610161629f0203ffff", expected: map[string]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+		{name: "int keyed map", input: "a201020304", expected: map[any]any{int64(1): int64(2), int64(3): int64(4)}},
+		{name: "epoch time", input: "c11a514b67b0", expected: time.Unix(1363896240, 0).UTC()},
+		{name: "unknown tag", input: "d74401020304", expected: []byte{1, 2, 3, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tc.input)
+
+			var actual any
+
+			err := CBOR{}.Decode(bytes.NewReader(input), &actual)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.Equalf(t, tc.expected, actual, "want %v, got %v", tc.expected, actual)
+		})
+	}
+}
+
+func TestCBOR_Decode_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "truncated argument", input: "1903"},
+		{name: "truncated string", input: "6449"},
+		{name: "huge length", input: "5bffffffffffffffff"},
+		{name: "reserved info", input: "1c"},
+		{name: "trailing data", input: "0000"},
+		{name: "invalid utf-8", input: "62c328"},
+		{name: "unterminated array", input: "9f01"},
+		{name: "mismatched chunk", input: "5f6161ff"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tc.input)
+
+			var actual any
+
+			err := CBOR{}.Decode(bytes.NewReader(input), &actual)
+
+			assert.Errorf(t, err, "want error, got nil")
+		})
+	}
+}
+
+func TestCBOR_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Tags []string `cbor:"tags"`
+	}
+
+	type book struct {
+		inner
+		ID        uint64            `cbor:"id"`
+		Title     string            `cbor:"title"`
+		Price     float32           `cbor:"price"`
+		Rating    *float64          `cbor:"rating"`
+		Published time.Time         `cbor:"published"`
+		Cover     []byte            `cbor:"cover"`
+		Meta      map[string]string `cbor:"meta"`
+		Authors   []struct {
+			Name string
+		} `cbor:"authors"`
+	}
+
+	rating := 4.5
+
+	expected := book{
+		inner:     inner{Tags: []string{"go", "http"}},
+		ID:        42,
+		Title:     "The Go Programming Language",
+		Price:     33.5,
+		Rating:    &rating,
+		Published: time.Date(2015, 10, 26, 0, 0, 0, 0, time.UTC),
+		Cover:     []byte{0xca, 0xfe},
+		Meta:      map[string]string{"isbn": "978-0134190440"},
+		Authors: []struct {
+			Name string
+		}{{Name: "Donovan"}, {Name: "Kernighan"}},
+	}
+
+	var buf bytes.Buffer
+
+	err := CBOR{}.Encode(&buf, expected)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	var actual book
+
+	err = CBOR{}.Decode(&buf, &actual)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	assert.Equalf(t, expected, actual, "want %v, got %v", expected, actual)
+}
@@ -0,0 +1,163 @@
+package codec
+
+import (
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"io"
+	"strings"
+	"sync"
+)
+
+type Codec interface {
+	MediaType() string
+	Encode(w io.Writer, v any) error
+	Decode(r io.Reader, v any) error
+}
+
+type Encodable interface {
+	CanEncode(v any) bool
+}
+
+func CanEncode(c Codec, v any) bool {
+	encodable, ok := c.(Encodable)
+
+	return !ok || encodable.CanEncode(v)
+}
+
+func NewRegistry(codecs ...Codec) *Registry {
+	registry := &Registry{
+		codecs:  make([]Codec, 0),
+		byMedia: make(map[string]Codec),
+	}
+
+	for _, c := range codecs {
+		registry.Register(c)
+	}
+
+	return registry
+}
+
+type Registry struct {
+	mu      sync.RWMutex
+	codecs  []Codec
+	byMedia map[string]Codec
+}
+
+func (registry *Registry) Register(c Codec, aliases ...string) {
+	registry.mu.Lock()
+
+	defer registry.mu.Unlock()
+
+	mediaType := negotiate.MediaType(c.MediaType())
+
+	_, exists := registry.byMedia[mediaType]
+
+	if !exists {
+		registry.codecs = append(registry.codecs, c)
+	}
+
+	for index, each := range registry.codecs {
+		if negotiate.MediaType(each.MediaType()) == mediaType {
+			registry.codecs[index] = c
+		}
+	}
+
+	registry.byMedia[mediaType] = c
+
+	for _, alias := range aliases {
+		registry.byMedia[negotiate.MediaType(alias)] = c
+	}
+}
+
+func (registry *Registry) Lookup(contentType string) (Codec, bool) {
+	registry.mu.RLock()
+
+	defer registry.mu.RUnlock()
+
+	mediaType := negotiate.MediaType(contentType)
+
+	c, ok := registry.byMedia[mediaType]
+
+	if ok {
+		return c, true
+	}
+
+	plus := strings.LastIndex(mediaType, "+")
+
+	if plus < 0 {
+		return nil, false
+	}
+
+	c, ok = registry.byMedia["application/"+mediaType[plus+1:]]
+
+	return c, ok
+}
+
+func (registry *Registry) MediaTypes() []string {
+	registry.mu.RLock()
+
+	defer registry.mu.RUnlock()
+
+	mediaTypes := make([]string, len(registry.codecs))
+
+	for index, c := range registry.codecs {
+		mediaTypes[index] = c.MediaType()
+	}
+
+	return mediaTypes
+}
+
+func (registry *Registry) Negotiate(accept string) (Codec, bool) {
+	mediaType, ok := negotiate.Media(accept, registry.MediaTypes())
+
+	if !ok {
+		return nil, false
+	}
+
+	return registry.Lookup(mediaType)
+}
+
+func (registry *Registry) NegotiateFor(accept string, v any) (Codec, bool) {
+	registry.mu.RLock()
+
+	offers := make([]string, 0, len(registry.codecs))
+
+	for _, c := range registry.codecs {
+		if CanEncode(c, v) {
+			offers = append(offers, c.MediaType())
+		}
+	}
+
+	registry.mu.RUnlock()
+
+	mediaType, ok := negotiate.Media(accept, offers)
+
+	if !ok {
+		return nil, false
+	}
+
+	return registry.Lookup(mediaType)
+}
+
+func Register(c Codec, aliases ...string) {
+	Default.Register(c, aliases...)
+}
+
+func Lookup(contentType string) (Codec, bool) {
+	return Default.Lookup(contentType)
+}
+
+func Negotiate(accept string) (Codec, bool) {
+	return Default.Negotiate(accept)
+}
+
+func defaultRegistry() *Registry {
+	registry := NewRegistry(JSON{}, XML{}, Form{}, CBOR{})
+
+	registry.Register(XML{}, "text/xml")
+
+	registry.Register(MessagePack{}, "application/x-msgpack", "application/vnd.msgpack")
+
+	return registry
+}
+
+var Default = defaultRegistry()
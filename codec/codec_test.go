@@ -0,0 +1,160 @@
+package codec
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRegistry_Lookup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		contentType string
+		expected    string
+		ok          bool
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			expected:    "application/json",
+			ok:          true,
+		},
+		{
+			name:        "json with parameters",
+			contentType: "application/json; charset=utf-8",
+			expected:    "application/json",
+			ok:          true,
+		},
+		{
+			name:        "json suffix",
+			contentType: "application/problem+json",
+			expected:    "application/json",
+			ok:          true,
+		},
+		{
+			name:        "xml alias",
+			contentType: "text/xml",
+			expected:    "application/xml",
+			ok:          true,
+		},
+		{
+			name:        "msgpack alias",
+			contentType: "application/x-msgpack",
+			expected:    "application/msgpack",
+			ok:          true,
+		},
+		{
+			name:        "cbor",
+			contentType: "application/cbor",
+			expected:    "application/cbor",
+			ok:          true,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			expected:    "application/x-www-form-urlencoded",
+			ok:          true,
+		},
+		{
+			name:        "unknown",
+			contentType: "text/csv",
+			ok:          false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ok := Lookup(tc.contentType)
+
+			assert.Equalf(t, tc.ok, ok, "want %v, got %v", tc.ok, ok)
+
+			if !tc.ok {
+				return
+			}
+
+			assert.Equalf(t, tc.expected, c.MediaType(), "want %v, got %v", tc.expected, c.MediaType())
+		})
+	}
+}
+
+func TestRegistry_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		accept   string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "empty accept prefers first registered",
+			accept:   "",
+			expected: "application/json",
+			ok:       true,
+		},
+		{
+			name:     "quality ordering",
+			accept:   "application/json;q=0.5, application/cbor",
+			expected: "application/cbor",
+			ok:       true,
+		},
+		{
+			name:     "wildcard subtype",
+			accept:   "application/*;q=0.2, text/html",
+			expected: "application/json",
+			ok:       true,
+		},
+		{
+			name:     "msgpack",
+			accept:   "application/msgpack",
+			expected: "application/msgpack",
+			ok:       true,
+		},
+		{
+			name:   "nothing acceptable",
+			accept: "text/html",
+			ok:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ok := Negotiate(tc.accept)
+
+			assert.Equalf(t, tc.ok, ok, "want %v, got %v", tc.ok, ok)
+
+			if !tc.ok {
+				return
+			}
+
+			assert.Equalf(t, tc.expected, c.MediaType(), "want %v, got %v", tc.expected, c.MediaType())
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry(JSON{})
+
+	registry.Register(CBOR{}, "application/x-cbor")
+
+	registry.Register(JSON{}, "text/json")
+
+	expected := []string{"application/json", "application/cbor"}
+
+	assert.Equalf(t, expected, registry.MediaTypes(), "want %v, got %v", expected, registry.MediaTypes())
+
+	c, ok := registry.Lookup("application/x-cbor")
+
+	assert.Truef(t, ok, "want ok == true, got %v", ok)
+
+	assert.Equalf(t, "application/cbor", c.MediaType(), "want %v, got %v", "application/cbor", c.MediaType())
+
+	c, ok = registry.Lookup("text/json")
+
+	assert.Truef(t, ok, "want ok == true, got %v", ok)
+
+	assert.Equalf(t, "application/json", c.MediaType(), "want %v, got %v", "application/json", c.MediaType())
+}
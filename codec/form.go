@@ -0,0 +1,183 @@
+package codec
+
+import (
+	"fmt"
+	"github.com/aakash-rajur/http/internal/convert"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+type Form struct{}
+
+func (Form) MediaType() string {
+	return "application/x-www-form-urlencoded"
+}
+
+func (Form) Encode(w io.Writer, v any) error {
+	values, err := formValues(reflect.ValueOf(v))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, values.Encode())
+
+	return err
+}
+
+func (Form) CanEncode(v any) bool {
+	value := indirect(reflect.ValueOf(v))
+
+	switch value.Kind() {
+	case reflect.Invalid, reflect.Struct:
+		return true
+	case reflect.Map:
+		return value.Type().Key().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+func (Form) Decode(r io.Reader, v any) error {
+	buffer, err := io.ReadAll(r)
+
+	if err != nil {
+		return err
+	}
+
+	values, err := url.ParseQuery(string(buffer))
+
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(v)
+
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("codec: form target must be a non-nil pointer, got %T", v)
+	}
+
+	target = target.Elem()
+
+	switch target.Kind() {
+	case reflect.Struct:
+		return decodeFormStruct(values, target)
+	case reflect.Map:
+		return decodeFormMap(values, target)
+	default:
+		return fmt.Errorf("codec: cannot decode form into %s", target.Type())
+	}
+}
+
+func formValues(v reflect.Value) (url.Values, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return url.Values{}, nil
+		}
+
+		v = v.Elem()
+	}
+
+	values := url.Values{}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("codec: cannot encode %s as form", v.Type())
+		}
+
+		iter := v.MapRange()
+
+		for iter.Next() {
+			values[iter.Key().String()] = formStrings(iter.Value())
+		}
+	case reflect.Struct:
+		for _, field := range fieldsOf(v.Type(), "form") {
+			fv := v.FieldByIndex(field.index)
+
+			if field.omitEmpty && fv.IsZero() {
+				continue
+			}
+
+			values[field.name] = formStrings(fv)
+		}
+	default:
+		return nil, fmt.Errorf("codec: cannot encode %s as form", v.Type())
+	}
+
+	return values, nil
+}
+
+func formStrings(v reflect.Value) []string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return []string{""}
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		out := make([]string, v.Len())
+
+		for i := range out {
+			out[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+
+		return out
+	}
+
+	return []string{fmt.Sprint(v.Interface())}
+}
+
+func decodeFormStruct(values url.Values, v reflect.Value) error {
+	for _, field := range fieldsOf(v.Type(), "form") {
+		raws, ok := values[field.name]
+
+		if !ok {
+			continue
+		}
+
+		err := convert.SetAll(v.FieldByIndex(field.index), raws)
+
+		if err != nil {
+			return fmt.Errorf("codec: form field %s: %w", field.name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeFormMap(values url.Values, v reflect.Value) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("codec: cannot decode form into %s", v.Type())
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+
+	for key, raws := range values {
+		item := reflect.New(v.Type().Elem()).Elem()
+
+		if item.Kind() == reflect.Interface {
+			item.Set(reflect.ValueOf(strings.Join(raws, ",")))
+
+			if len(raws) > 1 {
+				item.Set(reflect.ValueOf(raws))
+			}
+		} else {
+			err := convert.SetAll(item, raws)
+
+			if err != nil {
+				return fmt.Errorf("codec: form field %s: %w", key, err)
+			}
+		}
+
+		v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), item)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package codec
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestForm_Decode(t *testing.T) {
+	t.Parallel()
+
+	type search struct {
+		Query string   `form:"q"`
+		Page  int      `form:"page"`
+		Tags  []string `form:"tag"`
+		Exact *bool    `json:"exact"`
+	}
+
+	exact := true
+
+	testCases := []struct {
+		name     string
+		input    string
+		target   any
+		expected any
+	}{
+		{
+			name:     "struct",
+			input:    "q=go&page=2&tag=a&tag=b&exact=true&unknown=1",
+			target:   &search{},
+			expected: &search{Query: "go", Page: 2, Tags: []string{"a", "b"}, Exact: &exact},
+		},
+		{
+			name:     "url values",
+			input:    "a=1&a=2&b=3",
+			target:   &url.Values{},
+			expected: &url.Values{"a": {"1", "2"}, "b": {"3"}},
+		},
+		{
+			name:     "string map",
+			input:    "a=1&b=x%20y",
+			target:   &map[string]string{},
+			expected: &map[string]string{"a": "1", "b": "x y"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Form{}.Decode(strings.NewReader(tc.input), tc.target)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.Equalf(t, tc.expected, tc.target, "want %v, got %v", tc.expected, tc.target)
+		})
+	}
+}
+
+func TestForm_Decode_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  string
+		target any
+	}{
+		{name: "invalid number", input: "page=two", target: &struct {
+			Page int `form:"page"`
+		}{}},
+		{name: "invalid escape", input: "a=%zz", target: &url.Values{}},
+		{name: "non pointer", input: "a=1", target: url.Values{}},
+		{name: "unsupported target", input: "a=1", target: new(int)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Form{}.Decode(strings.NewReader(tc.input), tc.target)
+
+			assert.Errorf(t, err, "want error, got nil")
+		})
+	}
+}
+
+func TestForm_Encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name: "struct",
+			value: struct {
+				Query string   `form:"q"`
+				Tags  []string `form:"tag"`
+				Note  string   `form:"note,omitempty"`
+			}{Query: "go http", Tags: []string{"a", "b"}},
+			expected: "q=go+http&tag=a&tag=b",
+		},
+		{
+			name:     "url values",
+			value:    url.Values{"b": {"2"}, "a": {"1"}},
+			expected: "a=1&b=2",
+		},
+		{
+			name:     "any map",
+			value:    map[string]any{"n": 1, "ok": true},
+			expected: "n=1&ok=true",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := Form{}.Encode(&buf, tc.value)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.Equalf(t, tc.expected, buf.String(), "want %v, got %v", tc.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,796 @@
+package codec
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTruncated = errors.New("codec: unexpected end of data")
+	ErrTooDeep   = errors.New("codec: nesting too deep")
+	ErrTrailing  = errors.New("codec: trailing data after value")
+)
+
+const maxDepth = 512
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type pair struct {
+	key   any
+	value any
+}
+
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+type fieldsKey struct {
+	t   reflect.Type
+	tag string
+}
+
+var fieldsCache sync.Map
+
+func fieldsOf(t reflect.Type, tag string) []field {
+	key := fieldsKey{t, tag}
+
+	cached, ok := fieldsCache.Load(key)
+
+	if ok {
+		return cached.([]field)
+	}
+
+	fields := collectFields(t, tag, nil)
+
+	fieldsCache.Store(key, fields)
+
+	return fields
+}
+
+func collectFields(t reflect.Type, tag string, parent []int) []field {
+	fields := make([]field, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		name, omitEmpty, tagged := fieldTag(sf, tag)
+
+		if name == "-" {
+			continue
+		}
+
+		index := append(append([]int{}, parent...), i)
+
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(sf.Type, tag, index)...)
+
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		fields = append(fields, field{name: name, index: index, omitEmpty: omitEmpty})
+	}
+
+	return fields
+}
+
+func fieldTag(sf reflect.StructField, tag string) (string, bool, bool) {
+	for _, key := range []string{tag, "json"} {
+		value, ok := sf.Tag.Lookup(key)
+
+		if !ok {
+			continue
+		}
+
+		if value == "-" {
+			return "-", false, true
+		}
+
+		name, options, _ := strings.Cut(value, ",")
+
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		return name, omitEmpty, true
+	}
+
+	return sf.Name, false, false
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+type encoder interface {
+	writeNil()
+	writeBool(b bool)
+	writeInt(n int64)
+	writeUint(n uint64)
+	writeFloat(f float64, bits int)
+	writeString(s string)
+	writeBytes(b []byte)
+	writeArray(n int)
+	writeMap(n int)
+	writeTime(t time.Time)
+	tag() string
+	buffer() *bytes.Buffer
+	fork() encoder
+}
+
+func encode(w io.Writer, e encoder, v any) error {
+	err := marshal(e, reflect.ValueOf(v), 0)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = e.buffer().WriteTo(w)
+
+	return err
+}
+
+func marshal(e encoder, v reflect.Value, depth int) error {
+	if depth > maxDepth {
+		return ErrTooDeep
+	}
+
+	if !v.IsValid() {
+		e.writeNil()
+
+		return nil
+	}
+
+	if v.Type() == timeType {
+		e.writeTime(v.Interface().(time.Time))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			e.writeNil()
+
+			return nil
+		}
+
+		return marshal(e, v.Elem(), depth+1)
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+
+		if err != nil {
+			return err
+		}
+
+		e.writeString(string(text))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.writeBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.writeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.writeUint(v.Uint())
+	case reflect.Float32:
+		e.writeFloat(v.Float(), 32)
+	case reflect.Float64:
+		e.writeFloat(v.Float(), 64)
+	case reflect.String:
+		e.writeString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			e.writeNil()
+
+			return nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.writeBytes(v.Bytes())
+
+			return nil
+		}
+
+		return marshalArray(e, v, depth)
+	case reflect.Array:
+		return marshalArray(e, v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			e.writeNil()
+
+			return nil
+		}
+
+		return marshalMap(e, v, depth)
+	case reflect.Struct:
+		return marshalStruct(e, v, depth)
+	default:
+		return fmt.Errorf("codec: cannot encode %s", v.Type())
+	}
+
+	return nil
+}
+
+func marshalArray(e encoder, v reflect.Value, depth int) error {
+	e.writeArray(v.Len())
+
+	for i := 0; i < v.Len(); i++ {
+		err := marshal(e, v.Index(i), depth+1)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func marshalMap(e encoder, v reflect.Value, depth int) error {
+	type entry struct {
+		key   []byte
+		value []byte
+	}
+
+	entries := make([]entry, 0, v.Len())
+
+	iter := v.MapRange()
+
+	for iter.Next() {
+		key := e.fork()
+
+		err := marshal(key, iter.Key(), depth+1)
+
+		if err != nil {
+			return err
+		}
+
+		value := e.fork()
+
+		err = marshal(value, iter.Value(), depth+1)
+
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry{key.buffer().Bytes(), value.buffer().Bytes()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	e.writeMap(len(entries))
+
+	for _, each := range entries {
+		e.buffer().Write(each.key)
+
+		e.buffer().Write(each.value)
+	}
+
+	return nil
+}
+
+func marshalStruct(e encoder, v reflect.Value, depth int) error {
+	fields := fieldsOf(v.Type(), e.tag())
+
+	included := make([]field, 0, len(fields))
+
+	for _, each := range fields {
+		if each.omitEmpty && isEmpty(v.FieldByIndex(each.index)) {
+			continue
+		}
+
+		included = append(included, each)
+	}
+
+	e.writeMap(len(included))
+
+	for _, each := range included {
+		e.writeString(each.name)
+
+		err := marshal(e, v.FieldByIndex(each.index), depth+1)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || len(r.data)-r.pos < n {
+		return nil, ErrTruncated
+	}
+
+	chunk := r.data[r.pos : r.pos+n]
+
+	r.pos += n
+
+	return chunk, nil
+}
+
+func (r *reader) peek() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, ErrTruncated
+	}
+
+	return r.data[r.pos], nil
+}
+
+func (r *reader) capacity(n uint64) int {
+	remaining := uint64(len(r.data) - r.pos)
+
+	if n > remaining {
+		return int(remaining)
+	}
+
+	return int(n)
+}
+
+type binder struct {
+	tag string
+}
+
+func (r *reader) bounded(n uint64) int {
+	if n > uint64(len(r.data)-r.pos) {
+		return -1
+	}
+
+	return int(n)
+}
+
+type parser func(r *reader, depth int) (any, error)
+
+func decode(source io.Reader, v any, tag string, parse parser) error {
+	target := reflect.ValueOf(v)
+
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("codec: decode target must be a non-nil pointer, got %T", v)
+	}
+
+	data, err := io.ReadAll(source)
+
+	if err != nil {
+		return err
+	}
+
+	r := &reader{data: data}
+
+	item, err := parse(r, 0)
+
+	if err != nil {
+		return err
+	}
+
+	if r.pos != len(data) {
+		return ErrTrailing
+	}
+
+	return binder{tag}.assign(target.Elem(), item)
+}
+
+func (b binder) assign(v reflect.Value, item any) error {
+	if item == nil {
+		v.Set(reflect.Zero(v.Type()))
+
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return b.assign(v.Elem(), item)
+	}
+
+	if v.Type() == timeType {
+		return b.assignTime(v, item)
+	}
+
+	if v.Kind() == reflect.Interface {
+		if v.NumMethod() != 0 {
+			return mismatch(v, item)
+		}
+
+		v.Set(reflect.ValueOf(natural(item)))
+
+		return nil
+	}
+
+	text, ok := item.(string)
+
+	if ok && v.CanAddr() {
+		unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+
+		if ok {
+			return unmarshaler.UnmarshalText([]byte(text))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := item.(bool)
+
+		if !ok {
+			return mismatch(v, item)
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return b.assignInt(v, item)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return b.assignUint(v, item)
+	case reflect.Float32, reflect.Float64:
+		return b.assignFloat(v, item)
+	case reflect.String:
+		switch value := item.(type) {
+		case string:
+			v.SetString(value)
+		case []byte:
+			v.SetString(string(value))
+		default:
+			return mismatch(v, item)
+		}
+	case reflect.Slice:
+		return b.assignSlice(v, item)
+	case reflect.Array:
+		return b.assignArray(v, item)
+	case reflect.Map:
+		return b.assignMap(v, item)
+	case reflect.Struct:
+		return b.assignStruct(v, item)
+	default:
+		return mismatch(v, item)
+	}
+
+	return nil
+}
+
+func (b binder) assignTime(v reflect.Value, item any) error {
+	switch value := item.(type) {
+	case time.Time:
+		v.Set(reflect.ValueOf(value))
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, value)
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t))
+	case int64:
+		v.Set(reflect.ValueOf(time.Unix(value, 0)))
+	case uint64:
+		v.Set(reflect.ValueOf(time.Unix(int64(value), 0)))
+	case float64:
+		seconds, fraction := math.Modf(value)
+
+		v.Set(reflect.ValueOf(time.Unix(int64(seconds), int64(fraction*1e9))))
+	default:
+		return mismatch(v, item)
+	}
+
+	return nil
+}
+
+func (b binder) assignInt(v reflect.Value, item any) error {
+	var n int64
+
+	switch value := item.(type) {
+	case int64:
+		n = value
+	case uint64:
+		if value > math.MaxInt64 {
+			return overflow(v, item)
+		}
+
+		n = int64(value)
+	default:
+		return mismatch(v, item)
+	}
+
+	if v.OverflowInt(n) {
+		return overflow(v, item)
+	}
+
+	v.SetInt(n)
+
+	return nil
+}
+
+func (b binder) assignUint(v reflect.Value, item any) error {
+	var n uint64
+
+	switch value := item.(type) {
+	case int64:
+		if value < 0 {
+			return overflow(v, item)
+		}
+
+		n = uint64(value)
+	case uint64:
+		n = value
+	default:
+		return mismatch(v, item)
+	}
+
+	if v.OverflowUint(n) {
+		return overflow(v, item)
+	}
+
+	v.SetUint(n)
+
+	return nil
+}
+
+func (b binder) assignFloat(v reflect.Value, item any) error {
+	switch value := item.(type) {
+	case float64:
+		v.SetFloat(value)
+	case int64:
+		v.SetFloat(float64(value))
+	case uint64:
+		v.SetFloat(float64(value))
+	default:
+		return mismatch(v, item)
+	}
+
+	return nil
+}
+
+func (b binder) assignSlice(v reflect.Value, item any) error {
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		switch value := item.(type) {
+		case []byte:
+			v.SetBytes(append([]byte{}, value...))
+
+			return nil
+		case string:
+			v.SetBytes([]byte(value))
+
+			return nil
+		}
+	}
+
+	items, ok := item.([]any)
+
+	if !ok {
+		return mismatch(v, item)
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+
+	for i, each := range items {
+		err := b.assign(slice.Index(i), each)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	v.Set(slice)
+
+	return nil
+}
+
+func (b binder) assignArray(v reflect.Value, item any) error {
+	switch value := item.(type) {
+	case []byte:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return mismatch(v, item)
+		}
+
+		reflect.Copy(v, reflect.ValueOf(value))
+
+		return nil
+	case []any:
+		for i := 0; i < v.Len() && i < len(value); i++ {
+			err := b.assign(v.Index(i), value[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return mismatch(v, item)
+	}
+}
+
+func (b binder) assignMap(v reflect.Value, item any) error {
+	pairs, ok := item.([]pair)
+
+	if !ok {
+		return mismatch(v, item)
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(pairs)))
+	}
+
+	for _, each := range pairs {
+		key := reflect.New(v.Type().Key()).Elem()
+
+		err := b.assign(key, each.key)
+
+		if err != nil {
+			return err
+		}
+
+		value := reflect.New(v.Type().Elem()).Elem()
+
+		err = b.assign(value, each.value)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetMapIndex(key, value)
+	}
+
+	return nil
+}
+
+func (b binder) assignStruct(v reflect.Value, item any) error {
+	pairs, ok := item.([]pair)
+
+	if !ok {
+		return mismatch(v, item)
+	}
+
+	fields := fieldsOf(v.Type(), b.tag)
+
+	for _, each := range pairs {
+		name, ok := each.key.(string)
+
+		if !ok {
+			continue
+		}
+
+		index := lookupField(fields, name)
+
+		if index == nil {
+			continue
+		}
+
+		err := b.assign(fieldByIndex(v, index), each.value)
+
+		if err != nil {
+			return fmt.Errorf("codec: field %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func lookupField(fields []field, name string) []int {
+	for _, each := range fields {
+		if each.name == name {
+			return each.index
+		}
+	}
+
+	for _, each := range fields {
+		if strings.EqualFold(each.name, name) {
+			return each.index
+		}
+	}
+
+	return nil
+}
+
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+func natural(item any) any {
+	switch value := item.(type) {
+	case []any:
+		out := make([]any, len(value))
+
+		for i, each := range value {
+			out[i] = natural(each)
+		}
+
+		return out
+	case []pair:
+		stringKeys := true
+
+		for _, each := range value {
+			_, ok := each.key.(string)
+
+			stringKeys = stringKeys && ok
+		}
+
+		if stringKeys {
+			out := make(map[string]any, len(value))
+
+			for _, each := range value {
+				out[each.key.(string)] = natural(each.value)
+			}
+
+			return out
+		}
+
+		out := make(map[any]any, len(value))
+
+		for _, each := range value {
+			key := natural(each.key)
+
+			if key != nil && !reflect.TypeOf(key).Comparable() {
+				key = fmt.Sprint(key)
+			}
+
+			out[key] = natural(each.value)
+		}
+
+		return out
+	default:
+		return item
+	}
+}
+
+func mismatch(v reflect.Value, item any) error {
+	return fmt.Errorf("codec: cannot decode %T into %s", item, v.Type())
+}
+
+func overflow(v reflect.Value, item any) error {
+	return fmt.Errorf("codec: value %v overflows %s", item, v.Type())
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	return v
+}
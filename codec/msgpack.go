@@ -0,0 +1,400 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+var ErrMessagePack = errors.New("codec: malformed msgpack")
+
+const msgpackTimestamp byte = 0xff
+
+type MessagePack struct{}
+
+func (MessagePack) MediaType() string {
+	return "application/msgpack"
+}
+
+func (MessagePack) Encode(w io.Writer, v any) error {
+	return encode(w, &msgpackEncoder{}, v)
+}
+
+func (MessagePack) Decode(r io.Reader, v any) error {
+	return decode(r, v, "msgpack", parseMessagePack)
+}
+
+type msgpackEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *msgpackEncoder) length(n int, fix, fixMax byte, formats [3]byte) {
+	switch {
+	case fixMax > 0 && n <= int(fixMax):
+		e.buf.WriteByte(fix | byte(n))
+	case formats[0] != 0 && n <= math.MaxUint8:
+		e.buf.Write([]byte{formats[0], byte(n)})
+	case n <= math.MaxUint16:
+		e.buf.WriteByte(formats[1])
+
+		e.buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		e.buf.WriteByte(formats[2])
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	}
+}
+
+func (e *msgpackEncoder) writeNil() {
+	e.buf.WriteByte(0xc0)
+}
+
+func (e *msgpackEncoder) writeBool(b bool) {
+	if b {
+		e.buf.WriteByte(0xc3)
+
+		return
+	}
+
+	e.buf.WriteByte(0xc2)
+}
+
+func (e *msgpackEncoder) writeInt(n int64) {
+	switch {
+	case n >= 0:
+		e.writeUint(uint64(n))
+	case n >= -32:
+		e.buf.WriteByte(byte(int8(n)))
+	case n >= math.MinInt8:
+		e.buf.Write([]byte{0xd0, byte(int8(n))})
+	case n >= math.MinInt16:
+		e.buf.WriteByte(0xd1)
+
+		e.buf.Write(binary.BigEndian.AppendUint16(nil, uint16(int16(n))))
+	case n >= math.MinInt32:
+		e.buf.WriteByte(0xd2)
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(int32(n))))
+	default:
+		e.buf.WriteByte(0xd3)
+
+		e.buf.Write(binary.BigEndian.AppendUint64(nil, uint64(n)))
+	}
+}
+
+func (e *msgpackEncoder) writeUint(n uint64) {
+	switch {
+	case n <= 0x7f:
+		e.buf.WriteByte(byte(n))
+	case n <= math.MaxUint8:
+		e.buf.Write([]byte{0xcc, byte(n)})
+	case n <= math.MaxUint16:
+		e.buf.WriteByte(0xcd)
+
+		e.buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	case n <= math.MaxUint32:
+		e.buf.WriteByte(0xce)
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	default:
+		e.buf.WriteByte(0xcf)
+
+		e.buf.Write(binary.BigEndian.AppendUint64(nil, n))
+	}
+}
+
+func (e *msgpackEncoder) writeFloat(f float64, bits int) {
+	if bits == 32 {
+		e.buf.WriteByte(0xca)
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(f))))
+
+		return
+	}
+
+	e.buf.WriteByte(0xcb)
+
+	e.buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(f)))
+}
+
+func (e *msgpackEncoder) writeString(s string) {
+	e.length(len(s), 0xa0, 31, [3]byte{0xd9, 0xda, 0xdb})
+
+	e.buf.WriteString(s)
+}
+
+func (e *msgpackEncoder) writeBytes(b []byte) {
+	e.length(len(b), 0, 0, [3]byte{0xc4, 0xc5, 0xc6})
+
+	e.buf.Write(b)
+}
+
+func (e *msgpackEncoder) writeArray(n int) {
+	e.length(n, 0x90, 15, [3]byte{0, 0xdc, 0xdd})
+}
+
+func (e *msgpackEncoder) writeMap(n int) {
+	e.length(n, 0x80, 15, [3]byte{0, 0xde, 0xdf})
+}
+
+func (e *msgpackEncoder) writeTime(t time.Time) {
+	seconds, nanoseconds := t.Unix(), uint64(t.Nanosecond())
+
+	switch {
+	case seconds >= 0 && seconds <= math.MaxUint32 && nanoseconds == 0:
+		e.buf.Write([]byte{0xd6, msgpackTimestamp})
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(seconds)))
+	case seconds >= 0 && seconds < 1<<34:
+		e.buf.Write([]byte{0xd7, msgpackTimestamp})
+
+		e.buf.Write(binary.BigEndian.AppendUint64(nil, nanoseconds<<34|uint64(seconds)))
+	default:
+		e.buf.Write([]byte{0xc7, 12, msgpackTimestamp})
+
+		e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(nanoseconds)))
+
+		e.buf.Write(binary.BigEndian.AppendUint64(nil, uint64(seconds)))
+	}
+}
+
+func (e *msgpackEncoder) tag() string {
+	return "msgpack"
+}
+
+func (e *msgpackEncoder) buffer() *bytes.Buffer {
+	return &e.buf
+}
+
+func (e *msgpackEncoder) fork() encoder {
+	return &msgpackEncoder{}
+}
+
+func parseMessagePack(r *reader, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, ErrTooDeep
+	}
+
+	chunk, err := r.next(1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	format := chunk[0]
+
+	switch {
+	case format <= 0x7f:
+		return int64(format), nil
+	case format >= 0xe0:
+		return int64(int8(format)), nil
+	case format&0xf0 == 0x80:
+		return parseMessagePackMap(r, uint64(format&0x0f), depth)
+	case format&0xf0 == 0x90:
+		return parseMessagePackArray(r, uint64(format&0x0f), depth)
+	case format&0xe0 == 0xa0:
+		return parseMessagePackString(r, uint64(format&0x1f))
+	}
+
+	switch format {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := msgpackUint(r, 1<<(format-0xc4))
+
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := r.next(r.bounded(n))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte{}, data...), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := msgpackUint(r, 1<<(format-0xc7))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return parseMessagePackExtension(r, n)
+	case 0xca:
+		data, err := r.next(4)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+	case 0xcb:
+		data, err := r.next(8)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := msgpackUint(r, 1<<(format-0xcc))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+
+		return int64(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (format - 0xd0)
+
+		n, err := msgpackUint(r, size)
+
+		if err != nil {
+			return nil, err
+		}
+
+		shift := 64 - 8*size
+
+		return int64(n<<shift) >> shift, nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return parseMessagePackExtension(r, 1<<(format-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := msgpackUint(r, 1<<(format-0xd9))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return parseMessagePackString(r, n)
+	case 0xdc, 0xdd:
+		n, err := msgpackUint(r, 2<<(format-0xdc))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return parseMessagePackArray(r, n, depth)
+	case 0xde, 0xdf:
+		n, err := msgpackUint(r, 2<<(format-0xde))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return parseMessagePackMap(r, n, depth)
+	default:
+		return nil, fmt.Errorf("%w: unknown format 0x%02x", ErrMessagePack, format)
+	}
+}
+
+func msgpackUint(r *reader, size int) (uint64, error) {
+	data, err := r.next(size)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var n uint64
+
+	for _, b := range data {
+		n = n<<8 | uint64(b)
+	}
+
+	return n, nil
+}
+
+func parseMessagePackString(r *reader, n uint64) (any, error) {
+	data, err := r.next(r.bounded(n))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func parseMessagePackArray(r *reader, n uint64, depth int) (any, error) {
+	items := make([]any, 0, r.capacity(n))
+
+	for i := uint64(0); i < n; i++ {
+		item, err := parseMessagePack(r, depth+1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func parseMessagePackMap(r *reader, n uint64, depth int) (any, error) {
+	pairs := make([]pair, 0, r.capacity(n))
+
+	for i := uint64(0); i < n; i++ {
+		key, err := parseMessagePack(r, depth+1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseMessagePack(r, depth+1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, pair{key, value})
+	}
+
+	return pairs, nil
+}
+
+func parseMessagePackExtension(r *reader, n uint64) (any, error) {
+	kind, err := r.next(1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.next(r.bounded(n))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if kind[0] != msgpackTimestamp {
+		return append([]byte{}, data...), nil
+	}
+
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		value := binary.BigEndian.Uint64(data)
+
+		return time.Unix(int64(value&(1<<34-1)), int64(value>>34)).UTC(), nil
+	case 12:
+		nanoseconds := binary.BigEndian.Uint32(data[:4])
+
+		seconds := int64(binary.BigEndian.Uint64(data[4:]))
+
+		return time.Unix(seconds, int64(nanoseconds)).UTC(), nil
+	default:
+		return nil, fmt.Errorf("%w: invalid timestamp length %d", ErrMessagePack, len(data))
+	}
+}
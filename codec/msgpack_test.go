@@ -0,0 +1,170 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessagePack_Encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "nil", value: nil, expected: "c0"},
+		{name: "false", value: false, expected: "c2"},
+		{name: "true", value: true, expected: "c3"},
+		{name: "positive fixint", value: 127, expected: "7f"},
+		{name: "uint8", value: 128, expected: "cc80"},
+		{name: "uint16", value: 256, expected: "cd0100"},
+		{name: "uint32", value: 65536, expected: "ce00010000"},
+		{name: "uint64", value: uint64(math.MaxUint64), expected: "cfffffffffffffffff"},
+		{name: "negative fixint", value: -32, expected: "e0"},
+		{name: "int8", value: -33, expected: "d0df"},
+		{name: "int16", value: -129, expected: "d1ff7f"},
+		{name: "int32", value: -32769, expected: "d2ffff7fff"},
+		{name: "int64", value: int64(math.MinInt64), expected: "d38000000000000000"},
+		{name: "float32", value: float32(1.5), expected: "ca3fc00000"},
+		{name: "float64", value: 1.1, expected: "cb3ff199999999999a"},
+		{name: "fixstr", value: "abc", expected: "a3616263"},
+		{name: "str8", value: strings.Repeat("a", 32), expected: "d920" + strings.Repeat("61", 32)},
+		{name: "bin8", value: []byte{1, 2}, expected: "c4020102"},
+		{name: "fixarray", value: []int{1, 2}, expected: "920102"},
+		{name: "array16", value: make([]int, 16), expected: "dc0010" + strings.Repeat("00", 16)},
+		{name: "fixmap", value: map[string]int{"b": 2, "a": 1}, expected: "82a16101a16202"},
+		{name: "timestamp32", value: time.Unix(1, 0), expected: "d6ff00000001"},
+		{name: "timestamp64", value: time.Unix(1, 1), expected: "d7ff0000000400000001"},
+		{name: "timestamp96", value: time.Unix(-1, 0), expected: "c70cff00000000ffffffffffffffff"},
+		{
+			name: "struct with tags",
+			value: struct {
+				Name string `msgpack:"n"`
+				Skip string `json:"-"`
+			}{Name: "x", Skip: "y"},
+			expected: "81a16ea178",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := MessagePack{}.Encode(&buf, tc.value)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			actual := hex.EncodeToString(buf.Bytes())
+
+			assert.Equalf(t, tc.expected, actual, "want %v, got %v", tc.expected, actual)
+		})
+	}
+}
+
+func TestMessagePack_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{name: "negative fixint", input: "e0", expected: int64(-32)},
+		{name: "int16", input: "d1ff7f", expected: int64(-129)},
+		{name: "int64", input: "d38000000000000000", expected: int64(math.MinInt64)},
+		{name: "uint64", input: "cfffffffffffffffff", expected: uint64(math.MaxUint64)},
+		{name: "float32", input: "ca3fc00000", expected: 1.5},
+		{name: "str16", input: "da0003616263", expected: "abc"},
+		{name: "bin16", input: "c500020102", expected: []byte{1, 2}},
+		{name: "array32", input: "dd000000020102", expected: []any{int64(1), int64(2)}},
+		{name: "map16", input: "de0001a16101", expected: map[string]any{"a": int64(1)}},
+		{name: "timestamp32", input: "d6ff00000001", expected: time.Unix(1, 0).UTC()},
+		{name: "timestamp64", input: "d7ff0000000400000001", expected: time.Unix(1, 1).UTC()},
+		{name: "timestamp96", input: "c70cff00000000ffffffffffffffff", expected: time.Unix(-1, 0).UTC()},
+		{name: "application extension", input: "d40105", expected: []byte{5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tc.input)
+
+			var actual any
+
+			err := MessagePack{}.Decode(bytes.NewReader(input), &actual)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.Equalf(t, tc.expected, actual, "want %v, got %v", tc.expected, actual)
+		})
+	}
+}
+
+func TestMessagePack_Decode_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  string
+		target any
+	}{
+		{name: "never used", input: "c1", target: new(any)},
+		{name: "truncated", input: "cd01", target: new(any)},
+		{name: "huge array", input: "ddffffffff", target: new(any)},
+		{name: "trailing data", input: "c0c0", target: new(any)},
+		{name: "overflow", input: "cd0100", target: new(int8)},
+		{name: "negative into uint", input: "ff", target: new(uint)},
+		{name: "type mismatch", input: "a161", target: new(int)},
+		{name: "non pointer", input: "c0", target: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tc.input)
+
+			err := MessagePack{}.Decode(bytes.NewReader(input), tc.target)
+
+			assert.Errorf(t, err, "want error, got nil")
+		})
+	}
+}
+
+func TestMessagePack_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type book struct {
+		ID        int64          `msgpack:"id"`
+		Title     string         `json:"title"`
+		Price     float64        `msgpack:"price"`
+		Published time.Time      `msgpack:"published"`
+		Tags      []string       `msgpack:"tags,omitempty"`
+		Extra     map[string]any `msgpack:"extra"`
+	}
+
+	expected := book{
+		ID:        -7,
+		Title:     "Concurrency in Go",
+		Price:     39.99,
+		Published: time.Date(2017, 7, 19, 0, 0, 0, 500, time.UTC),
+		Extra:     map[string]any{"pages": int64(238), "hardcover": false},
+	}
+
+	var buf bytes.Buffer
+
+	err := MessagePack{}.Encode(&buf, expected)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	var actual book
+
+	err = MessagePack{}.Decode(&buf, &actual)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	assert.Equalf(t, expected, actual, "want %v, got %v", expected, actual)
+}
@@ -0,0 +1,25 @@
+package codec
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+)
+
+type XML struct{}
+
+func (XML) MediaType() string {
+	return "application/xml"
+}
+
+func (XML) Encode(w io.Writer, v any) error {
+	return xml.NewEncoder(w).Encode(v)
+}
+
+func (XML) CanEncode(v any) bool {
+	return indirect(reflect.ValueOf(v)).Kind() != reflect.Map
+}
+
+func (XML) Decode(r io.Reader, v any) error {
+	return xml.NewDecoder(r).Decode(v)
+}
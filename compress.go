@@ -0,0 +1,344 @@
+package http
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+func Compress(config CompressConfig) Middleware {
+	cfg := saneCompressConfig(config)
+
+	pools := make(map[string]*sync.Pool)
+
+	for _, encoding := range cfg.Encodings {
+		pools[encoding] = newCompressorPool(encoding, cfg.Level)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		negotiate.AddVary(w.Header(), "Accept-Encoding")
+
+		rw, ok := UnwrapResponseWriter(w)
+
+		if !ok || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next(r)
+
+			return
+		}
+
+		encoding, ok := negotiate.Encoding(r.Header.Get("Accept-Encoding"), cfg.Encodings)
+
+		if !ok || encoding == "identity" {
+			next(r)
+
+			return
+		}
+
+		cw := &compressWriter{
+			ResponseWriter: rw.ResponseWriter,
+			config:         cfg,
+			encoding:       encoding,
+			pool:           pools[encoding],
+		}
+
+		rw.ResponseWriter = cw
+
+		defer func() {
+			rw.ResponseWriter = cw.ResponseWriter
+
+			cw.close()
+		}()
+
+		next(r)
+	}
+}
+
+func saneCompressConfig(in CompressConfig) CompressConfig {
+	out := CompressConfig{
+		Level:                gzip.DefaultCompression,
+		MinLength:            1024,
+		Encodings:            []string{"gzip", "deflate"},
+		ExcludedContentTypes: append(slices.Clone(defaultExcludedContentTypes), in.ExcludedContentTypes...),
+	}
+
+	if in.Level != 0 {
+		out.Level = in.Level
+	}
+
+	if in.Level < gzip.HuffmanOnly || in.Level > gzip.BestCompression {
+		panic(fmt.Sprintf("http: invalid compression level %d", in.Level))
+	}
+
+	if in.MinLength > 0 {
+		out.MinLength = in.MinLength
+	}
+
+	for _, encoding := range in.Encodings {
+		if encoding != "gzip" && encoding != "deflate" {
+			panic(fmt.Sprintf("http: unsupported compression encoding %q", encoding))
+		}
+	}
+
+	if len(in.Encodings) > 0 {
+		out.Encodings = in.Encodings
+	}
+
+	return out
+}
+
+func newCompressorPool(encoding string, level int) *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			if encoding == "deflate" {
+				zw, _ := zlib.NewWriterLevel(nil, level)
+
+				return zw
+			}
+
+			gw, _ := gzip.NewWriterLevel(nil, level)
+
+			return gw
+		},
+	}
+}
+
+type CompressConfig struct {
+	Level                int
+	MinLength            int
+	Encodings            []string
+	ExcludedContentTypes []string
+}
+
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	config     CompressConfig
+	encoding   string
+	pool       *sync.Pool
+	status     int
+	buffer     []byte
+	decided    bool
+	compressor compressor
+}
+
+func (cw *compressWriter) WriteHeader(statusCode int) {
+	if statusCode >= 100 && statusCode < 200 {
+		cw.ResponseWriter.WriteHeader(statusCode)
+
+		return
+	}
+
+	if cw.status != 0 || cw.decided {
+		return
+	}
+
+	cw.status = statusCode
+
+	if !cw.eligible() {
+		_ = cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(buffer []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided && !cw.eligible() {
+		_ = cw.decide(false)
+	}
+
+	if cw.decided {
+		return cw.write(buffer)
+	}
+
+	cw.buffer = append(cw.buffer, buffer...)
+
+	if len(cw.buffer) < cw.config.MinLength {
+		return len(buffer), nil
+	}
+
+	err := cw.decide(true)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(buffer), nil
+}
+
+func (cw *compressWriter) Flush() {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided {
+		_ = cw.decide(len(cw.buffer) >= cw.config.MinLength && cw.eligible())
+	}
+
+	if cw.compressor != nil {
+		_ = cw.compressor.Flush()
+	}
+
+	flusher, ok := cw.ResponseWriter.(http.Flusher)
+
+	if ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	return hijacker.Hijack()
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) eligible() bool {
+	header := cw.Header()
+
+	switch cw.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+
+	if err == nil && length < cw.config.MinLength {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+
+	if contentType == "" {
+		return true
+	}
+
+	mediaType := negotiate.MediaType(contentType)
+
+	for _, excluded := range cw.config.ExcludedContentTypes {
+		if negotiate.MediaMatches(excluded, mediaType) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+
+	header := cw.Header()
+
+	if len(cw.buffer) > 0 && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", http.DetectContentType(cw.buffer))
+
+		compress = compress && cw.eligible()
+	}
+
+	if compress {
+		header.Del("Content-Length")
+
+		header.Del("Accept-Ranges")
+
+		header.Set("Content-Encoding", cw.encoding)
+
+		etag := header.Get("ETag")
+
+		if etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+
+		cw.compressor = cw.pool.Get().(compressor)
+
+		cw.compressor.Reset(cw.ResponseWriter)
+	}
+
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+
+	buffer := cw.buffer
+
+	cw.buffer = nil
+
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	_, err := cw.write(buffer)
+
+	return err
+}
+
+func (cw *compressWriter) write(buffer []byte) (int, error) {
+	if cw.compressor != nil {
+		return cw.compressor.Write(buffer)
+	}
+
+	return cw.ResponseWriter.Write(buffer)
+}
+
+func (cw *compressWriter) close() {
+	if !cw.decided && cw.status != 0 {
+		_ = cw.decide(false)
+	}
+
+	if cw.compressor == nil {
+		return
+	}
+
+	_ = cw.compressor.Close()
+
+	cw.compressor.Reset(io.Discard)
+
+	cw.pool.Put(cw.compressor)
+
+	cw.compressor = nil
+}
+
+var defaultExcludedContentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"image/avif",
+	"video/*",
+	"audio/*",
+	"font/woff",
+	"font/woff2",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zstd",
+	"application/x-bzip2",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/pdf",
+	"text/event-stream",
+}
@@ -0,0 +1,308 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+
+	large := strings.Repeat("the alchemist ", 100)
+
+	png := "\x89PNG\x0d\x0a\x1a\x0a" + strings.Repeat("\x00", 200)
+
+	router := NewRouter()
+
+	router.Use(Compress(CompressConfig{MinLength: 64}))
+
+	router.GetFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		w.Header().Set("Content-Length", "1400")
+
+		w.Header().Set("ETag", `"v1"`)
+
+		_, _ = w.Write([]byte(large))
+	})
+
+	router.GetFunc("/chunks", func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 100; i += 1 {
+			_, _ = w.Write([]byte("chunk "))
+		}
+	})
+
+	router.GetFunc("/small", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("tiny"))
+	})
+
+	router.GetFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+
+		_, _ = w.Write([]byte(png))
+	})
+
+	router.GetFunc("/sniffed", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(png))
+	})
+
+	router.GetFunc("/created", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+
+		_, _ = w.Write([]byte(large))
+	})
+
+	router.GetFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name            string
+		target          string
+		headers         map[string]string
+		wantStatus      int
+		wantEncoding    string
+		wantBody        string
+		wantEtag        string
+		wantLength      string
+		wantContentType string
+	}{
+		{
+			name:            "should gzip large bodies",
+			target:          "/large",
+			headers:         map[string]string{"Accept-Encoding": "gzip, deflate"},
+			wantStatus:      http.StatusOK,
+			wantEncoding:    "gzip",
+			wantBody:        large,
+			wantEtag:        `W/"v1"`,
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should honour q-values",
+			target:          "/large",
+			headers:         map[string]string{"Accept-Encoding": "gzip;q=0.2, deflate;q=0.8"},
+			wantStatus:      http.StatusOK,
+			wantEncoding:    "deflate",
+			wantBody:        large,
+			wantEtag:        `W/"v1"`,
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should compress bodies written in chunks",
+			target:          "/chunks",
+			headers:         map[string]string{"Accept-Encoding": "*"},
+			wantStatus:      http.StatusOK,
+			wantEncoding:    "gzip",
+			wantBody:        strings.Repeat("chunk ", 100),
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should keep the status code",
+			target:          "/created",
+			headers:         map[string]string{"Accept-Encoding": "gzip"},
+			wantStatus:      http.StatusCreated,
+			wantEncoding:    "gzip",
+			wantBody:        large,
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should not compress when the client refuses",
+			target:          "/large",
+			headers:         map[string]string{"Accept-Encoding": "gzip;q=0, br"},
+			wantStatus:      http.StatusOK,
+			wantBody:        large,
+			wantEtag:        `"v1"`,
+			wantLength:      "1400",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should not compress range requests",
+			target:          "/large",
+			headers:         map[string]string{"Accept-Encoding": "gzip", "Range": "bytes=0-9"},
+			wantStatus:      http.StatusOK,
+			wantBody:        large,
+			wantEtag:        `"v1"`,
+			wantLength:      "1400",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should skip small bodies",
+			target:          "/small",
+			headers:         map[string]string{"Accept-Encoding": "gzip"},
+			wantStatus:      http.StatusOK,
+			wantBody:        "tiny",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "should skip compressed content types",
+			target:          "/image",
+			headers:         map[string]string{"Accept-Encoding": "gzip"},
+			wantStatus:      http.StatusOK,
+			wantBody:        png,
+			wantContentType: "image/png",
+		},
+		{
+			name:            "should skip sniffed compressed content types",
+			target:          "/sniffed",
+			headers:         map[string]string{"Accept-Encoding": "gzip"},
+			wantStatus:      http.StatusOK,
+			wantBody:        png,
+			wantContentType: "image/png",
+		},
+		{
+			name:       "should skip bodiless responses",
+			target:     "/empty",
+			headers:    map[string]string{"Accept-Encoding": "gzip"},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			encoding := w.Header().Get("Content-Encoding")
+
+			assert.Equalf(t, tt.wantEncoding, encoding, "want %v, got %v", tt.wantEncoding, encoding)
+
+			body := decompress(t, encoding, w.Body.Bytes())
+
+			assert.Equalf(t, tt.wantBody, body, "want %v, got %v", tt.wantBody, body)
+
+			assert.Equalf(t, tt.wantEtag, w.Header().Get("ETag"), "want %v, got %v", tt.wantEtag, w.Header().Get("ETag"))
+
+			assert.Equalf(t, tt.wantLength, w.Header().Get("Content-Length"), "want %v, got %v", tt.wantLength, w.Header().Get("Content-Length"))
+
+			assert.Equalf(t, tt.wantContentType, w.Header().Get("Content-Type"), "want %v, got %v", tt.wantContentType, w.Header().Get("Content-Type"))
+
+			assert.Equalf(t, "Accept-Encoding", w.Header().Get("Vary"), "want Accept-Encoding, got %v", w.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestCompress_Flush(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	flushed := 0
+
+	router := NewRouter()
+
+	router.Use(Compress(CompressConfig{MinLength: 16}))
+
+	router.GetFunc("/events", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(strings.Repeat("first ", 10)))
+
+		rw.(http.Flusher).Flush()
+
+		flushed = w.Body.Len()
+
+		_, _ = rw.Write([]byte("second"))
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	router.ServeHTTP(w, r)
+
+	assert.Truef(t, w.Flushed, "want the recorder flushed, got %v", w.Flushed)
+
+	assert.Greaterf(t, flushed, 0, "want compressed bytes before the handler returns, got %v", flushed)
+
+	want := strings.Repeat("first ", 10) + "second"
+
+	got := decompress(t, "gzip", w.Body.Bytes())
+
+	assert.Equalf(t, want, got, "want %v, got %v", want, got)
+}
+
+func TestCompress_Logger(t *testing.T) {
+	t.Parallel()
+
+	logs := NewMemoryWriter()
+
+	router := NewRouter()
+
+	router.Use(Logger(LoggerConfig{
+		Output: logs,
+		LogFormatter: func(params LogFormatterParams) string {
+			return params.ResponseContentEncoding
+		},
+	}))
+
+	router.Use(Compress(CompressConfig{}))
+
+	router.GetFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", 2048)))
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/large", nil)
+
+	r.Header.Set("Accept-Encoding", "deflate")
+
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equalf(t, "deflate", logs.Content, "want deflate, got %v", logs.Content)
+}
+
+func Test_saneCompressConfig(t *testing.T) {
+	t.Parallel()
+
+	assert.Panicsf(t, func() { saneCompressConfig(CompressConfig{Level: 12}) }, "want invalid levels to panic")
+
+	assert.Panicsf(t, func() { saneCompressConfig(CompressConfig{Encodings: []string{"br"}}) }, "want unsupported encodings to panic")
+
+	got := saneCompressConfig(CompressConfig{ExcludedContentTypes: []string{"application/x-custom"}})
+
+	assert.Equalf(t, 1024, got.MinLength, "want 1024, got %v", got.MinLength)
+
+	assert.Equalf(t, []string{"gzip", "deflate"}, got.Encodings, "want gzip, deflate, got %v", got.Encodings)
+
+	assert.Containsf(t, got.ExcludedContentTypes, "application/x-custom", "want custom exclusions, got %v", got.ExcludedContentTypes)
+
+	assert.NotContainsf(t, defaultExcludedContentTypes, "application/x-custom", "want defaults untouched, got %v", defaultExcludedContentTypes)
+}
+
+func decompress(t *testing.T, encoding string, body []byte) string {
+	var reader io.Reader = bytes.NewReader(body)
+
+	switch encoding {
+	case "gzip":
+		gr, err := gzip.NewReader(reader)
+
+		assert.NoErrorf(t, err, "gzip.NewReader() err = %v", err)
+
+		reader = gr
+	case "deflate":
+		zr, err := zlib.NewReader(reader)
+
+		assert.NoErrorf(t, err, "zlib.NewReader() err = %v", err)
+
+		reader = zr
+	}
+
+	buffer, err := io.ReadAll(reader)
+
+	assert.NoErrorf(t, err, "io.ReadAll() err = %v", err)
+
+	return string(buffer)
+}
@@ -0,0 +1,115 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	h "github.com/aakash-rajur/http"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func New(config Config) h.Middleware {
+	cfg := saneConfig(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next h.Next) {
+		limiter, priority := cfg.Limiter, cfg.Priority
+
+		route, ok := h.RouteFromRequest(r)
+
+		if ok {
+			value, found := route.Value(limiterKey)
+
+			if found {
+				limiter = value.(*Limiter)
+			}
+
+			value, found = route.Value(priorityKey)
+
+			if found {
+				priority = value.(Priority)
+			}
+		}
+
+		if limiter == nil {
+			next(r)
+
+			return
+		}
+
+		release, err := limiter.Acquire(r.Context(), priority)
+
+		if err != nil {
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(cfg.RetryAfter.Seconds())), 10))
+
+			h.HandleError(w, r, err)
+
+			return
+		}
+
+		dropped := true
+
+		defer func() {
+			release(dropped)
+		}()
+
+		next(r)
+
+		dropped = overloaded(w, r)
+	}
+}
+
+func WithLimiter(limiter *Limiter) h.RouteOption {
+	return h.WithMetadata(limiterKey, limiter)
+}
+
+func WithPriority(priority Priority) h.RouteOption {
+	return h.WithMetadata(priorityKey, priority)
+}
+
+func saneConfig(in Config) Config {
+	out := Config{
+		Limiter:    in.Limiter,
+		Priority:   in.Priority,
+		RetryAfter: time.Second,
+	}
+
+	if in.RetryAfter > 0 {
+		out.RetryAfter = in.RetryAfter
+	}
+
+	return out
+}
+
+type Config struct {
+	Limiter    *Limiter
+	Priority   Priority
+	RetryAfter time.Duration
+}
+
+func overloaded(w http.ResponseWriter, r *http.Request) bool {
+	if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		return true
+	}
+
+	rw, ok := h.UnwrapResponseWriter(w)
+
+	if !ok {
+		return false
+	}
+
+	return rw.StatusCode == http.StatusServiceUnavailable ||
+		rw.StatusCode == http.StatusGatewayTimeout ||
+		errors.Is(rw.Err, http.ErrHandlerTimeout)
+}
+
+var ErrOverloaded = &h.HTTPError{
+	Code:    http.StatusServiceUnavailable,
+	Message: "server overloaded",
+}
+
+const (
+	limiterKey  = "http_concurrency_limiter"
+	priorityKey = "http_concurrency_priority"
+)
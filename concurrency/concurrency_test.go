@@ -0,0 +1,123 @@
+package concurrency
+
+import (
+	h "github.com/aakash-rajur/http"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(LimiterConfig{Limit: Fixed(1)})
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{Limiter: limiter, RetryAfter: 2 * time.Second}))
+
+	started, unblock := make(chan struct{}), make(chan struct{})
+
+	router.GetFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+
+		<-unblock
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	router.GetFunc("/books", ok)
+
+	router.GetFunc("/health", ok, WithPriority(Critical))
+
+	router.GetFunc("/reports", ok, WithLimiter(NewLimiter(LimiterConfig{Limit: Fixed(1)})))
+
+	router.GetFunc("/unlimited", ok, WithLimiter(nil))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+	}()
+
+	<-started
+
+	tests := []struct {
+		name           string
+		target         string
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{
+			name:           "should shed when saturated",
+			target:         "/books",
+			wantStatus:     http.StatusServiceUnavailable,
+			wantRetryAfter: "2",
+		},
+		{
+			name:       "should admit critical routes",
+			target:     "/health",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should use route limiter",
+			target:     "/reports",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should skip routes without limiter",
+			target:     "/unlimited",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		assert.Equalf(t, tt.wantStatus, rec.Code, "%s: want %v, got %v", tt.name, tt.wantStatus, rec.Code)
+
+		gotRetryAfter := rec.Header().Get("Retry-After")
+
+		assert.Equalf(t, tt.wantRetryAfter, gotRetryAfter, "%s: want %v, got %v", tt.name, tt.wantRetryAfter, gotRetryAfter)
+	}
+
+	close(unblock)
+
+	<-done
+
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books", nil))
+
+	assert.Equalf(t, http.StatusOK, rec.Code, "want %v, got %v", http.StatusOK, rec.Code)
+}
+
+func TestNew_Dropped(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(LimiterConfig{Limit: NewAIMD(AIMDConfig{Initial: 8, Backoff: 0.5})})
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{Limiter: limiter}))
+
+	router.GetFunc("/busy", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/busy", nil))
+
+	got := limiter.Limit()
+
+	assert.Equalf(t, 4, got, "want %v, got %v", 4, got)
+}
@@ -0,0 +1,222 @@
+package concurrency
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type Sample struct {
+	Latency  time.Duration
+	InFlight int
+	Dropped  bool
+}
+
+type Limit interface {
+	Limit() int
+	Observe(sample Sample)
+}
+
+func Fixed(limit int) Limit {
+	if limit <= 0 {
+		panic(fmt.Sprintf("concurrency: invalid limit %d", limit))
+	}
+
+	return fixed(limit)
+}
+
+type fixed int
+
+func (f fixed) Limit() int {
+	return int(f)
+}
+
+func (f fixed) Observe(sample Sample) {}
+
+func NewAIMD(config AIMDConfig) *AIMD {
+	cfg := saneAIMDConfig(config)
+
+	return &AIMD{config: cfg, limit: float64(cfg.Initial)}
+}
+
+func saneAIMDConfig(in AIMDConfig) AIMDConfig {
+	out := AIMDConfig{
+		Initial:   20,
+		Min:       1,
+		Max:       1000,
+		Backoff:   0.9,
+		Threshold: in.Threshold,
+	}
+
+	if in.Initial > 0 {
+		out.Initial = in.Initial
+	}
+
+	if in.Min > 0 {
+		out.Min = in.Min
+	}
+
+	if in.Max > 0 {
+		out.Max = in.Max
+	}
+
+	if in.Backoff > 0 && in.Backoff < 1 {
+		out.Backoff = in.Backoff
+	}
+
+	if out.Min > out.Max {
+		panic(fmt.Sprintf("concurrency: min limit %d above max limit %d", out.Min, out.Max))
+	}
+
+	out.Initial = clamp(out.Initial, out.Min, out.Max)
+
+	return out
+}
+
+type AIMDConfig struct {
+	Initial   int
+	Min       int
+	Max       int
+	Backoff   float64
+	Threshold time.Duration
+}
+
+type AIMD struct {
+	config AIMDConfig
+	limit  float64
+}
+
+func (a *AIMD) Limit() int {
+	return int(a.limit)
+}
+
+func (a *AIMD) Observe(sample Sample) {
+	cfg := a.config
+
+	overloaded := sample.Dropped || (cfg.Threshold > 0 && sample.Latency > cfg.Threshold)
+
+	switch {
+	case overloaded:
+		a.limit = a.limit * cfg.Backoff
+
+	case sample.InFlight*2 >= int(a.limit):
+		a.limit += 1
+
+	default:
+		return
+	}
+
+	a.limit = math.Max(float64(cfg.Min), math.Min(float64(cfg.Max), a.limit))
+}
+
+func NewGradient(config GradientConfig) *Gradient {
+	cfg := saneGradientConfig(config)
+
+	return &Gradient{config: cfg, limit: float64(cfg.Initial)}
+}
+
+func saneGradientConfig(in GradientConfig) GradientConfig {
+	out := GradientConfig{
+		Initial:   20,
+		Min:       1,
+		Max:       1000,
+		Tolerance: 1.5,
+		Smoothing: 0.2,
+		Window:    600,
+	}
+
+	if in.Initial > 0 {
+		out.Initial = in.Initial
+	}
+
+	if in.Min > 0 {
+		out.Min = in.Min
+	}
+
+	if in.Max > 0 {
+		out.Max = in.Max
+	}
+
+	if in.Tolerance >= 1 {
+		out.Tolerance = in.Tolerance
+	}
+
+	if in.Smoothing > 0 && in.Smoothing <= 1 {
+		out.Smoothing = in.Smoothing
+	}
+
+	if in.Window > 0 {
+		out.Window = in.Window
+	}
+
+	if out.Min > out.Max {
+		panic(fmt.Sprintf("concurrency: min limit %d above max limit %d", out.Min, out.Max))
+	}
+
+	out.Initial = clamp(out.Initial, out.Min, out.Max)
+
+	return out
+}
+
+type GradientConfig struct {
+	Initial   int
+	Min       int
+	Max       int
+	Tolerance float64
+	Smoothing float64
+	Window    int
+}
+
+type Gradient struct {
+	config   GradientConfig
+	limit    float64
+	baseline float64
+}
+
+func (g *Gradient) Limit() int {
+	return int(g.limit)
+}
+
+func (g *Gradient) Observe(sample Sample) {
+	cfg := g.config
+
+	latency := float64(sample.Latency)
+
+	if latency <= 0 {
+		return
+	}
+
+	if g.baseline == 0 {
+		g.baseline = latency
+	} else {
+		g.baseline += (latency - g.baseline) / float64(cfg.Window)
+	}
+
+	if sample.InFlight*2 < int(g.limit) && !sample.Dropped {
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1, cfg.Tolerance*g.baseline/latency))
+
+	if sample.Dropped {
+		gradient = 0.5
+	}
+
+	next := g.limit*gradient + math.Sqrt(g.limit)
+
+	next = g.limit*(1-cfg.Smoothing) + next*cfg.Smoothing
+
+	g.limit = math.Max(float64(cfg.Min), math.Min(float64(cfg.Max), next))
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+
+	if value > max {
+		return max
+	}
+
+	return value
+}
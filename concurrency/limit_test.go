@@ -0,0 +1,125 @@
+package concurrency
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAIMD(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  AIMDConfig
+		samples []Sample
+		want    int
+	}{
+		{
+			name:    "should start at initial limit",
+			config:  AIMDConfig{Initial: 10},
+			samples: nil,
+			want:    10,
+		},
+		{
+			name:   "should increase when utilised",
+			config: AIMDConfig{Initial: 10},
+			samples: []Sample{
+				{Latency: time.Millisecond, InFlight: 5},
+				{Latency: time.Millisecond, InFlight: 6},
+			},
+			want: 12,
+		},
+		{
+			name:   "should hold when underutilised",
+			config: AIMDConfig{Initial: 10},
+			samples: []Sample{
+				{Latency: time.Millisecond, InFlight: 1},
+			},
+			want: 10,
+		},
+		{
+			name:   "should back off on drops",
+			config: AIMDConfig{Initial: 10, Backoff: 0.5},
+			samples: []Sample{
+				{Latency: time.Millisecond, InFlight: 10, Dropped: true},
+			},
+			want: 5,
+		},
+		{
+			name:   "should back off above latency threshold",
+			config: AIMDConfig{Initial: 10, Backoff: 0.5, Threshold: 100 * time.Millisecond},
+			samples: []Sample{
+				{Latency: time.Second, InFlight: 10},
+			},
+			want: 5,
+		},
+		{
+			name:   "should respect bounds",
+			config: AIMDConfig{Initial: 2, Min: 2, Max: 3, Backoff: 0.5},
+			samples: []Sample{
+				{InFlight: 2, Dropped: true},
+				{InFlight: 2},
+				{InFlight: 2},
+				{InFlight: 2},
+			},
+			want: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		limit := NewAIMD(tt.config)
+
+		for _, sample := range tt.samples {
+			limit.Observe(sample)
+		}
+
+		got := limit.Limit()
+
+		assert.Equalf(t, tt.want, got, "%s: want %v, got %v", tt.name, tt.want, got)
+	}
+}
+
+func TestGradient(t *testing.T) {
+	t.Parallel()
+
+	limit := NewGradient(GradientConfig{Initial: 16, Smoothing: 1, Tolerance: 1})
+
+	limit.Observe(Sample{Latency: 10 * time.Millisecond, InFlight: 16})
+
+	got := limit.Limit()
+
+	assert.Equalf(t, 20, got, "want %v, got %v", 20, got)
+
+	for i := 0; i < 5; i++ {
+		limit.Observe(Sample{Latency: time.Second, InFlight: 20})
+	}
+
+	got = limit.Limit()
+
+	assert.Lessf(t, got, 20, "want below %v, got %v", 20, got)
+
+	before := got
+
+	limit.Observe(Sample{Latency: 10 * time.Millisecond, InFlight: 1})
+
+	got = limit.Limit()
+
+	assert.Equalf(t, before, got, "want %v, got %v", before, got)
+}
+
+func TestFixed(t *testing.T) {
+	t.Parallel()
+
+	limit := Fixed(3)
+
+	limit.Observe(Sample{Dropped: true})
+
+	got := limit.Limit()
+
+	assert.Equalf(t, 3, got, "want %v, got %v", 3, got)
+
+	assert.PanicsWithValue(t, "concurrency: invalid limit 0", func() {
+		Fixed(0)
+	})
+}
@@ -0,0 +1,227 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Priority int
+
+const (
+	Low Priority = iota - 1
+	Normal
+	High
+	Critical
+)
+
+func NewLimiter(config LimiterConfig) *Limiter {
+	cfg := saneLimiterConfig(config)
+
+	return &Limiter{config: cfg, now: time.Now}
+}
+
+func saneLimiterConfig(in LimiterConfig) LimiterConfig {
+	out := LimiterConfig{
+		Limit:        in.Limit,
+		MaxQueue:     in.MaxQueue,
+		QueueTimeout: 100 * time.Millisecond,
+	}
+
+	if out.Limit == nil {
+		out.Limit = Fixed(100)
+	}
+
+	if out.MaxQueue < 0 {
+		out.MaxQueue = 0
+	}
+
+	if in.QueueTimeout > 0 {
+		out.QueueTimeout = in.QueueTimeout
+	}
+
+	return out
+}
+
+type LimiterConfig struct {
+	Limit        Limit
+	MaxQueue     int
+	QueueTimeout time.Duration
+}
+
+type Limiter struct {
+	config   LimiterConfig
+	now      func() time.Time
+	mu       sync.Mutex
+	inFlight int
+	queue    []*waiter
+}
+
+type waiter struct {
+	priority Priority
+	ready    chan struct{}
+	admitted bool
+	done     bool
+}
+
+func (l *Limiter) Acquire(ctx context.Context, priority Priority) (func(dropped bool), error) {
+	l.mu.Lock()
+
+	if priority >= Critical || (l.inFlight < l.config.Limit.Limit() && !l.queued(priority)) {
+		release := l.admit()
+
+		l.mu.Unlock()
+
+		return release, nil
+	}
+
+	w, ok := l.enqueue(priority)
+
+	l.mu.Unlock()
+
+	if !ok {
+		return nil, ErrOverloaded
+	}
+
+	timer := time.NewTimer(l.config.QueueTimeout)
+
+	defer timer.Stop()
+
+	select {
+	case <-w.ready:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+
+	defer l.mu.Unlock()
+
+	if w.admitted {
+		return l.release(l.now()), nil
+	}
+
+	if !w.done {
+		l.remove(w)
+	}
+
+	return nil, ErrOverloaded
+}
+
+func (l *Limiter) InFlight() int {
+	l.mu.Lock()
+
+	defer l.mu.Unlock()
+
+	return l.inFlight
+}
+
+func (l *Limiter) Queued() int {
+	l.mu.Lock()
+
+	defer l.mu.Unlock()
+
+	return len(l.queue)
+}
+
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+
+	defer l.mu.Unlock()
+
+	return l.config.Limit.Limit()
+}
+
+func (l *Limiter) admit() func(dropped bool) {
+	l.inFlight += 1
+
+	return l.release(l.now())
+}
+
+func (l *Limiter) release(start time.Time) func(dropped bool) {
+	inFlight := l.inFlight
+
+	var once sync.Once
+
+	return func(dropped bool) {
+		once.Do(func() {
+			l.mu.Lock()
+
+			defer l.mu.Unlock()
+
+			l.inFlight -= 1
+
+			l.config.Limit.Observe(Sample{
+				Latency:  l.now().Sub(start),
+				InFlight: inFlight,
+				Dropped:  dropped,
+			})
+
+			l.drain()
+		})
+	}
+}
+
+func (l *Limiter) drain() {
+	for len(l.queue) > 0 && l.inFlight < l.config.Limit.Limit() {
+		w := l.queue[0]
+
+		l.queue = l.queue[1:]
+
+		l.inFlight += 1
+
+		w.admitted, w.done = true, true
+
+		close(w.ready)
+	}
+}
+
+func (l *Limiter) queued(priority Priority) bool {
+	return len(l.queue) > 0 && l.queue[0].priority >= priority
+}
+
+func (l *Limiter) enqueue(priority Priority) (*waiter, bool) {
+	if l.config.MaxQueue == 0 {
+		return nil, false
+	}
+
+	if len(l.queue) >= l.config.MaxQueue {
+		last := l.queue[len(l.queue)-1]
+
+		if last.priority >= priority {
+			return nil, false
+		}
+
+		l.queue = l.queue[:len(l.queue)-1]
+
+		last.done = true
+
+		close(last.ready)
+	}
+
+	w := &waiter{priority: priority, ready: make(chan struct{})}
+
+	index := len(l.queue)
+
+	for index > 0 && l.queue[index-1].priority < priority {
+		index -= 1
+	}
+
+	l.queue = append(l.queue, nil)
+
+	copy(l.queue[index+1:], l.queue[index:])
+
+	l.queue[index] = w
+
+	return w, true
+}
+
+func (l *Limiter) remove(w *waiter) {
+	for i, queued := range l.queue {
+		if queued == w {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package concurrency
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(LimiterConfig{Limit: Fixed(1), MaxQueue: 2, QueueTimeout: time.Second})
+
+	release, err := limiter.Acquire(context.Background(), Normal)
+
+	assert.NoError(t, err)
+
+	order := make(chan string, 3)
+
+	acquire := func(name string, priority Priority) {
+		release, err := limiter.Acquire(context.Background(), priority)
+
+		if err != nil {
+			order <- name + ":" + err.Error()
+
+			return
+		}
+
+		order <- name
+
+		release(false)
+	}
+
+	go acquire("low", Low)
+
+	waitFor(t, func() bool { return limiter.Queued() == 1 })
+
+	go acquire("normal", Normal)
+
+	waitFor(t, func() bool { return limiter.Queued() == 2 })
+
+	go acquire("high", High)
+
+	got := []string{<-order}
+
+	release(false)
+
+	got = append(got, <-order, <-order)
+
+	want := []string{"low:server overloaded", "high", "normal"}
+
+	assert.Equalf(t, want, got, "want %v, got %v", want, got)
+
+	gotInFlight := limiter.InFlight()
+
+	assert.Equalf(t, 0, gotInFlight, "want %v, got %v", 0, gotInFlight)
+}
+
+func TestLimiter_Shed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   LimiterConfig
+		priority Priority
+		wantErr  error
+	}{
+		{
+			name:     "should shed without queue",
+			config:   LimiterConfig{Limit: Fixed(1)},
+			priority: Normal,
+			wantErr:  ErrOverloaded,
+		},
+		{
+			name:     "should shed after queue timeout",
+			config:   LimiterConfig{Limit: Fixed(1), MaxQueue: 1, QueueTimeout: 10 * time.Millisecond},
+			priority: Normal,
+			wantErr:  ErrOverloaded,
+		},
+		{
+			name:     "should always admit critical",
+			config:   LimiterConfig{Limit: Fixed(1)},
+			priority: Critical,
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		limiter := NewLimiter(tt.config)
+
+		release, err := limiter.Acquire(context.Background(), Normal)
+
+		assert.NoError(t, err)
+
+		_, gotErr := limiter.Acquire(context.Background(), tt.priority)
+
+		assert.Equalf(t, tt.wantErr, gotErr, "%s: want %v, got %v", tt.name, tt.wantErr, gotErr)
+
+		release(false)
+
+		gotQueued := limiter.Queued()
+
+		assert.Equalf(t, 0, gotQueued, "%s: want %v, got %v", tt.name, 0, gotQueued)
+	}
+}
+
+func TestLimiter_Critical(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(LimiterConfig{Limit: Fixed(1)})
+
+	releases := make([]func(dropped bool), 0, 3)
+
+	for _, priority := range []Priority{Normal, Critical, Critical} {
+		release, err := limiter.Acquire(context.Background(), priority)
+
+		assert.NoErrorf(t, err, "priority %v: want no error, got %v", priority, err)
+
+		releases = append(releases, release)
+	}
+
+	gotInFlight := limiter.InFlight()
+
+	assert.Equalf(t, 3, gotInFlight, "want %v, got %v", 3, gotInFlight)
+
+	_, err := limiter.Acquire(context.Background(), High)
+
+	assert.Equalf(t, ErrOverloaded, err, "want %v, got %v", ErrOverloaded, err)
+
+	for _, release := range releases {
+		release(false)
+	}
+
+	gotInFlight = limiter.InFlight()
+
+	assert.Equalf(t, 0, gotInFlight, "want %v, got %v", 0, gotInFlight)
+}
+
+func TestLimiter_Canceled(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(LimiterConfig{Limit: Fixed(1), MaxQueue: 1, QueueTimeout: time.Minute})
+
+	release, _ := limiter.Acquire(context.Background(), Normal)
+
+	defer release(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	_, err := limiter.Acquire(ctx, Normal)
+
+	assert.Equalf(t, ErrOverloaded, err, "want %v, got %v", ErrOverloaded, err)
+}
+
+func TestLimiter_Adaptive(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewLimiter(LimiterConfig{Limit: NewAIMD(AIMDConfig{Initial: 4, Backoff: 0.5})})
+
+	release, _ := limiter.Acquire(context.Background(), Normal)
+
+	release(true)
+
+	release(true)
+
+	got := limiter.Limit()
+
+	assert.Equalf(t, 2, got, "want %v, got %v", 2, got)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"github.com/aakash-rajur/http/bind"
+	"github.com/aakash-rajur/http/params"
+	"github.com/aakash-rajur/http/render"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+type Context struct {
+	Writer  http.ResponseWriter
+	Request *http.Request
+	params  params.Params
+	query   url.Values
+	route   *Route
+	values  map[string]any
+}
+
+type ContextHandler func(c *Context) error
+
+func (handler ContextHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c := acquireContext(w, r)
+
+	defer releaseContext(c)
+
+	err := handler(c)
+
+	if err != nil {
+		HandleError(c.Writer, c.Request, err)
+	}
+}
+
+func WrapHandler(handler http.Handler) ContextHandler {
+	return func(c *Context) error {
+		handler.ServeHTTP(c.Writer, c.Request)
+
+		return nil
+	}
+}
+
+var contextPool = sync.Pool{
+	New: func() any {
+		return &Context{values: make(map[string]any)}
+	},
+}
+
+func acquireContext(w http.ResponseWriter, r *http.Request) *Context {
+	c := contextPool.Get().(*Context)
+
+	c.Writer, c.Request = w, r
+
+	c.params, _ = params.FromRequest(r)
+
+	c.route, _ = RouteFromRequest(r)
+
+	return c
+}
+
+func releaseContext(c *Context) {
+	c.Writer, c.Request, c.params, c.query, c.route = nil, nil, nil, nil, nil
+
+	clear(c.values)
+
+	contextPool.Put(c)
+}
+
+func (c *Context) Context() context.Context {
+	return c.Request.Context()
+}
+
+func (c *Context) Route() *Route {
+	return c.route
+}
+
+func (c *Context) Param(key string) string {
+	return c.params.Get(key, "")
+}
+
+func (c *Context) ParamInt(key string) (int, error) {
+	return params.Int(c.Request, key)
+}
+
+func (c *Context) Query(key string) string {
+	return c.QueryDefault(key, "")
+}
+
+func (c *Context) QueryDefault(key, fallback string) string {
+	if c.query == nil {
+		c.query = c.Request.URL.Query()
+	}
+
+	values, ok := c.query[key]
+
+	if !ok || len(values) == 0 {
+		return fallback
+	}
+
+	return values[0]
+}
+
+func (c *Context) QueryValues(key string) []string {
+	if c.query == nil {
+		c.query = c.Request.URL.Query()
+	}
+
+	return c.query[key]
+}
+
+func (c *Context) Header(key string) string {
+	return c.Request.Header.Get(key)
+}
+
+func (c *Context) SetHeader(key, value string) {
+	c.Writer.Header().Set(key, value)
+}
+
+func (c *Context) Cookie(name string) (*http.Cookie, error) {
+	return c.Request.Cookie(name)
+}
+
+func (c *Context) SetCookie(cookie *http.Cookie) {
+	http.SetCookie(c.Writer, cookie)
+}
+
+func (c *Context) Set(key string, value any) {
+	c.values[key] = value
+}
+
+func (c *Context) Get(key string) (any, bool) {
+	value, ok := c.values[key]
+
+	return value, ok
+}
+
+func (c *Context) Bind(dst any) error {
+	return bindError(bind.Bind(c.Request, dst))
+}
+
+func (c *Context) Status(code int) {
+	c.Writer.WriteHeader(code)
+}
+
+func (c *Context) NoContent() error {
+	c.Writer.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (c *Context) JSON(status int, value any) error {
+	return render.JSON(c.Writer, status, value)
+}
+
+func (c *Context) XML(status int, value any) error {
+	return render.XML(c.Writer, status, value)
+}
+
+func (c *Context) Text(status int, text string) error {
+	return render.Text(c.Writer, status, text)
+}
+
+func (c *Context) Negotiate(status int, value any) error {
+	return render.Negotiate(c.Writer, c.Request, status, value)
+}
+
+func (c *Context) Redirect(status int, location string) error {
+	if status < http.StatusMultipleChoices || status > http.StatusPermanentRedirect {
+		return Errorf(http.StatusInternalServerError, "invalid redirect status %d", status)
+	}
+
+	http.Redirect(c.Writer, c.Request, location, status)
+
+	return nil
+}
+
+func (c *Context) Error(err error) {
+	HandleError(c.Writer, c.Request, err)
+}
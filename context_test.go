@@ -0,0 +1,226 @@
+package http
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContextHandler(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Use(func(w http.ResponseWriter, r *http.Request, next Next) {
+		next(r)
+	})
+
+	router.Get(
+		"/users/{id}/books",
+		ContextHandler(func(c *Context) error {
+			id, err := c.ParamInt("id")
+
+			if err != nil {
+				return err
+			}
+
+			c.Set("user", id)
+
+			user, _ := c.Get("user")
+
+			session, err := c.Cookie("session")
+
+			if err != nil {
+				return Errorf(http.StatusUnauthorized, "missing session")
+			}
+
+			c.SetHeader("X-Route", c.Route().String())
+
+			c.SetCookie(&http.Cookie{Name: "seen", Value: "1"})
+
+			return c.JSON(http.StatusOK, map[string]any{
+				"user":    user,
+				"param":   c.Param("id"),
+				"sort":    c.QueryDefault("sort", "name"),
+				"tags":    c.QueryValues("tag"),
+				"limit":   c.Query("limit"),
+				"session": session.Value,
+				"agent":   c.Header("User-Agent"),
+			})
+		}),
+	)
+
+	router.Post(
+		"/books",
+		ContextHandler(func(c *Context) error {
+			var req struct {
+				Name string `json:"name" validate:"required"`
+			}
+
+			err := c.Bind(&req)
+
+			if err != nil {
+				return err
+			}
+
+			return c.Text(http.StatusCreated, req.Name)
+		}),
+	)
+
+	router.Get(
+		"/old",
+		ContextHandler(func(c *Context) error {
+			return c.Redirect(http.StatusMovedPermanently, "/new")
+		}),
+	)
+
+	router.Delete(
+		"/books/{id}",
+		ContextHandler(func(c *Context) error {
+			return c.NoContent()
+		}),
+	)
+
+	router.Get(
+		"/wrapped",
+		WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("plain"))
+		})),
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     map[string]string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantHeader map[string]string
+	}{
+		{
+			name:       "should expose params, query, cookies and values",
+			method:     http.MethodGet,
+			target:     "/users/7/books?tag=a&tag=b&limit=5",
+			header:     map[string]string{"Cookie": "session=abc", "User-Agent": "test"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"agent":"test","limit":"5","param":"7","session":"abc","sort":"name","tags":["a","b"],"user":7}`,
+			wantHeader: map[string]string{"X-Route": "GET /users/{id}/books", "Set-Cookie": "seen=1"},
+		},
+		{
+			name:       "should map returned errors",
+			method:     http.MethodGet,
+			target:     "/users/7/books",
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   `{"detail":"missing session","instance":"/users/7/books","status":401,"title":"Unauthorized"}`,
+		},
+		{
+			name:       "should map param errors",
+			method:     http.MethodGet,
+			target:     "/users/seven/books",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "should bind and validate",
+			method:     http.MethodPost,
+			target:     "/books",
+			header:     map[string]string{"Content-Type": "application/json"},
+			body:       `{"name":"Emma"}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   "Emma",
+		},
+		{
+			name:       "should reject unsupported bodies",
+			method:     http.MethodPost,
+			target:     "/books",
+			header:     map[string]string{"Content-Type": "text/csv"},
+			body:       "name",
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "should redirect",
+			method:     http.MethodGet,
+			target:     "/old",
+			wantStatus: http.StatusMovedPermanently,
+			wantHeader: map[string]string{"Location": "/new"},
+		},
+		{
+			name:       "should respond without content",
+			method:     http.MethodDelete,
+			target:     "/books/1",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "should wrap plain handlers",
+			method:     http.MethodGet,
+			target:     "/wrapped",
+			wantStatus: http.StatusOK,
+			wantBody:   "plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v: %v", tt.wantStatus, w.Code, w.Body.String())
+
+			switch {
+			case tt.wantBody == "":
+			case strings.HasPrefix(tt.wantBody, "{"):
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			default:
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+
+			for key, value := range tt.wantHeader {
+				assert.Equalf(t, value, w.Header().Get(key), "want %v, got %v", value, w.Header().Get(key))
+			}
+		})
+	}
+}
+
+func TestContext_Pooled(t *testing.T) {
+	leaked := errors.New("value leaked from a previous request")
+
+	handler := ContextHandler(func(c *Context) error {
+		_, ok := c.Get("key")
+
+		if ok {
+			return leaked
+		}
+
+		c.Set("key", "value")
+
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equalf(t, http.StatusOK, w.Code, "request %d: want %v, got %v", i, http.StatusOK, w.Code)
+	}
+
+	w, r := httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		c := acquireContext(w, r)
+
+		releaseContext(c)
+	})
+
+	assert.Equalf(t, float64(0), allocs, "want pooled contexts, got %v allocs", allocs)
+}
@@ -0,0 +1,253 @@
+package http
+
+import (
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"net/http"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func CORS(config CORSConfig) Middleware {
+	global := newCORSPolicy(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		origin := r.Header.Get("Origin")
+
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if origin != "" && preflight && global.preflight(w, r, origin) {
+			return
+		}
+
+		policy := global
+
+		override, ok := routeValue[*corsPolicy](r, corsKey)
+
+		if ok {
+			policy = override
+		}
+
+		policy.actual(w, r, origin)
+
+		next(r)
+	}
+}
+
+func WithCORS(config CORSConfig) RouteOption {
+	return WithMetadata(corsKey, newCORSPolicy(config))
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowOriginFunc  func(r *http.Request, origin string) bool
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+type corsPolicy struct {
+	config    CORSConfig
+	any       bool
+	exact     map[string]bool
+	wildcards [][2]string
+	allowed   map[string]bool
+	exposed   string
+}
+
+func newCORSPolicy(config CORSConfig) *corsPolicy {
+	policy := &corsPolicy{
+		config:  config,
+		exact:   make(map[string]bool),
+		allowed: make(map[string]bool),
+		exposed: strings.Join(config.ExposedHeaders, ", "),
+	}
+
+	for _, origin := range config.AllowedOrigins {
+		origin = strings.ToLower(origin)
+
+		before, after, found := strings.Cut(origin, "*")
+
+		switch {
+		case origin == "*":
+			policy.any = true
+		case found:
+			policy.wildcards = append(policy.wildcards, [2]string{before, after})
+		default:
+			policy.exact[origin] = true
+		}
+	}
+
+	if policy.any && config.AllowCredentials {
+		panic("http: cors cannot allow credentials for any origin")
+	}
+
+	headers := config.AllowedHeaders
+
+	if len(headers) == 0 {
+		headers = safelistedHeaders
+	}
+
+	for _, header := range headers {
+		policy.allowed[strings.ToLower(header)] = true
+	}
+
+	return policy
+}
+
+func (policy *corsPolicy) preflight(w http.ResponseWriter, r *http.Request, origin string) bool {
+	router, ok := routerFromRequest(r)
+
+	if !ok {
+		return false
+	}
+
+	methods := router.AllowedMethods(r)
+
+	if len(methods) == 0 {
+		return false
+	}
+
+	requested := r.Header.Get("Access-Control-Request-Method")
+
+	route := router.lookupMethod(r, requested)
+
+	if route != nil {
+		override, ok := route.Value(corsKey)
+
+		if ok {
+			policy = override.(*corsPolicy)
+		}
+	}
+
+	header := w.Header()
+
+	negotiate.AddVary(header, "Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers")
+
+	header.Set("Allow", strings.Join(methods, ", "))
+
+	headers, ok := policy.requestedHeaders(r)
+
+	if !policy.allows(r, origin) || !slices.Contains(methods, requested) || !ok {
+		w.WriteHeader(http.StatusNoContent)
+
+		return true
+	}
+
+	policy.allowOrigin(header, origin)
+
+	header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+	if len(headers) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	}
+
+	if policy.config.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.config.MaxAge.Seconds())))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return true
+}
+
+func (policy *corsPolicy) actual(w http.ResponseWriter, r *http.Request, origin string) {
+	header := w.Header()
+
+	if !policy.any || policy.config.AllowCredentials || policy.config.AllowOriginFunc != nil {
+		negotiate.AddVary(header, "Origin")
+	}
+
+	if origin == "" || !policy.allows(r, origin) {
+		return
+	}
+
+	policy.allowOrigin(header, origin)
+
+	if policy.exposed != "" {
+		header.Set("Access-Control-Expose-Headers", policy.exposed)
+	}
+}
+
+func (policy *corsPolicy) allowOrigin(header http.Header, origin string) {
+	if policy.any {
+		header.Set("Access-Control-Allow-Origin", "*")
+
+		return
+	}
+
+	header.Set("Access-Control-Allow-Origin", origin)
+
+	if policy.config.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (policy *corsPolicy) allows(r *http.Request, origin string) bool {
+	if origin == "" {
+		return false
+	}
+
+	if policy.any {
+		return true
+	}
+
+	lower := strings.ToLower(origin)
+
+	if policy.exact[lower] {
+		return true
+	}
+
+	for _, wildcard := range policy.wildcards {
+		prefix, suffix := wildcard[0], wildcard[1]
+
+		if len(lower) <= len(prefix)+len(suffix) {
+			continue
+		}
+
+		if !strings.HasPrefix(lower, prefix) || !strings.HasSuffix(lower, suffix) {
+			continue
+		}
+
+		label := lower[len(prefix) : len(lower)-len(suffix)]
+
+		if !strings.ContainsAny(label, "/:@") {
+			return true
+		}
+	}
+
+	if policy.config.AllowOriginFunc != nil {
+		return policy.config.AllowOriginFunc(r, origin)
+	}
+
+	return false
+}
+
+func (policy *corsPolicy) requestedHeaders(r *http.Request) ([]string, bool) {
+	headers := make([]string, 0)
+
+	for _, value := range r.Header.Values("Access-Control-Request-Headers") {
+		for _, each := range strings.Split(value, ",") {
+			each = strings.ToLower(strings.TrimSpace(each))
+
+			if each == "" {
+				continue
+			}
+
+			if !policy.allowed["*"] && !policy.allowed[each] {
+				return nil, false
+			}
+
+			headers = append(headers, textproto.CanonicalMIMEHeaderKey(each))
+		}
+	}
+
+	return headers, true
+}
+
+var safelistedHeaders = []string{"Accept", "Accept-Language", "Content-Language", "Content-Type"}
+
+const corsKey = "http_cors"
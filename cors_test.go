@@ -0,0 +1,280 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Use(CORS(CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.example.org"},
+		AllowOriginFunc: func(r *http.Request, origin string) bool {
+			return origin == "http://localhost:3000"
+		},
+		AllowedHeaders:   []string{"Content-Type", "X-Token"},
+		ExposedHeaders:   []string{"X-Total"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}))
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	router.GetFunc("/books", ok)
+
+	router.PostFunc("/books", ok)
+
+	router.GetFunc("/public", ok, WithCORS(CORSConfig{AllowedOrigins: []string{"*"}}))
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		headers     map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "should answer preflight with registered methods",
+			method: http.MethodOptions,
+			target: "/books",
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "content-type, x-token",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, HEAD, POST",
+				"Access-Control-Allow-Headers":     "Content-Type, X-Token",
+				"Access-Control-Max-Age":           "600",
+				"Vary":                             "Origin, Access-Control-Request-Method, Access-Control-Request-Headers",
+			},
+		},
+		{
+			name:   "should allow wildcard subdomains",
+			method: http.MethodOptions,
+			target: "/books",
+			headers: map[string]string{
+				"Origin":                        "https://api.example.org",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://api.example.org",
+				"Access-Control-Allow-Methods": "GET, HEAD, POST",
+				"Access-Control-Allow-Headers": "",
+			},
+		},
+		{
+			name:   "should not match the apex with a wildcard subdomain",
+			method: http.MethodOptions,
+			target: "/books",
+			headers: map[string]string{
+				"Origin":                        "https://example.org",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			name:   "should reject methods that are not registered",
+			method: http.MethodOptions,
+			target: "/books",
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": http.MethodDelete,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Allow":                       "GET, HEAD, POST",
+			},
+		},
+		{
+			name:   "should reject headers that are not allowed",
+			method: http.MethodOptions,
+			target: "/books",
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "x-debug",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			name:   "should leave preflight for unknown paths to the router",
+			method: http.MethodOptions,
+			target: "/authors",
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			wantStatus: http.StatusNotFound,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "",
+				"Vary":                         "Origin, Accept",
+			},
+		},
+		{
+			name:   "should decorate allowed actual requests",
+			method: http.MethodGet,
+			target: "/books",
+			headers: map[string]string{
+				"Origin": "https://app.example.com",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Total",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name:   "should allow origins through the predicate",
+			method: http.MethodPost,
+			target: "/books",
+			headers: map[string]string{
+				"Origin": "http://localhost:3000",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "http://localhost:3000",
+			},
+		},
+		{
+			name:   "should serve disallowed origins without cors headers",
+			method: http.MethodGet,
+			target: "/books",
+			headers: map[string]string{
+				"Origin": "https://evil.test",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			},
+		},
+		{
+			name:       "should vary on origin for same origin requests",
+			method:     http.MethodGet,
+			target:     "/books",
+			headers:    map[string]string{},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			},
+		},
+		{
+			name:   "should apply per route policies to actual requests",
+			method: http.MethodGet,
+			target: "/public",
+			headers: map[string]string{
+				"Origin": "https://evil.test",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+				"Vary":                             "",
+			},
+		},
+		{
+			name:   "should apply per route policies to preflight requests",
+			method: http.MethodOptions,
+			target: "/public",
+			headers: map[string]string{
+				"Origin":                        "https://evil.test",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "GET, HEAD",
+			},
+		},
+		{
+			name:   "should allow safelisted headers by default",
+			method: http.MethodOptions,
+			target: "/public",
+			headers: map[string]string{
+				"Origin":                         "https://evil.test",
+				"Access-Control-Request-Method":  http.MethodGet,
+				"Access-Control-Request-Headers": "content-type, accept",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Headers": "Content-Type, Accept",
+			},
+		},
+		{
+			name:   "should reject other headers by default",
+			method: http.MethodOptions,
+			target: "/public",
+			headers: map[string]string{
+				"Origin":                         "https://evil.test",
+				"Access-Control-Request-Method":  http.MethodGet,
+				"Access-Control-Request-Headers": "authorization",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Headers": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			for key, want := range tt.wantHeaders {
+				got := strings.Join(w.Header().Values(key), ", ")
+
+				assert.Equalf(t, want, got, "%s: want %v, got %v", key, want, got)
+			}
+		})
+	}
+}
+
+func TestCORS_AnyOriginWithCredentials(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "http: cors cannot allow credentials for any origin", func() {
+		CORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+	})
+
+	assert.PanicsWithValue(t, "http: cors cannot allow credentials for any origin", func() {
+		WithCORS(CORSConfig{AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true})
+	})
+}
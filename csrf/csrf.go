@@ -0,0 +1,272 @@
+package csrf
+
+import (
+	"context"
+	"crypto/subtle"
+	h "github.com/aakash-rajur/http"
+	"net/http"
+	"time"
+)
+
+type Mode int
+
+const (
+	DoubleSubmit Mode = iota
+	Synchronizer
+)
+
+func New(config Config) h.Middleware {
+	cfg := saneConfig(config)
+
+	p := &protection{config: cfg, trusted: trustedOrigins(cfg.TrustedOrigins)}
+
+	return func(w http.ResponseWriter, r *http.Request, next h.Next) {
+		route, ok := h.RouteFromRequest(r)
+
+		if ok {
+			exempt, _ := route.Value(exemptKey)
+
+			if exempt == true {
+				next(r)
+
+				return
+			}
+		}
+
+		token, err := p.token(w, r)
+
+		if err != nil {
+			h.HandleError(w, r, err)
+
+			return
+		}
+
+		if token != nil {
+			w.Header().Add("Vary", "Cookie")
+
+			w.Header().Set(cfg.Header, mask(token))
+
+			r = r.WithContext(context.WithValue(r.Context(), tokenKey, &tokenContext{token: token, field: cfg.Field}))
+		}
+
+		if safeMethod(r.Method) {
+			next(r)
+
+			return
+		}
+
+		err = p.check(r, token)
+
+		if err != nil {
+			h.HandleError(w, r, err)
+
+			return
+		}
+
+		next(r)
+	}
+}
+
+func Exempt() h.RouteOption {
+	return h.WithMetadata(exemptKey, true)
+}
+
+func saneConfig(in Config) Config {
+	out := Config{
+		Mode:           in.Mode,
+		Secret:         in.Secret,
+		Session:        in.Session,
+		Store:          in.Store,
+		TrustedOrigins: in.TrustedOrigins,
+		Header:         "X-CSRF-Token",
+		Field:          "csrf_token",
+		Cookie: CookieConfig{
+			Name:     "_csrf",
+			Path:     "/",
+			Domain:   in.Cookie.Domain,
+			MaxAge:   12 * time.Hour,
+			Insecure: in.Cookie.Insecure,
+			SameSite: http.SameSiteLaxMode,
+		},
+	}
+
+	if in.Header != "" {
+		out.Header = in.Header
+	}
+
+	if in.Field != "" {
+		out.Field = in.Field
+	}
+
+	if in.Cookie.Name != "" {
+		out.Cookie.Name = in.Cookie.Name
+	}
+
+	if in.Cookie.Path != "" {
+		out.Cookie.Path = in.Cookie.Path
+	}
+
+	if in.Cookie.MaxAge > 0 {
+		out.Cookie.MaxAge = in.Cookie.MaxAge
+	}
+
+	if in.Cookie.SameSite != 0 {
+		out.Cookie.SameSite = in.Cookie.SameSite
+	}
+
+	switch out.Mode {
+	case DoubleSubmit:
+		if len(out.Secret) == 0 {
+			out.Secret = newToken()
+		}
+
+	case Synchronizer:
+		if out.Session == nil {
+			panic("csrf: synchronizer mode requires Session")
+		}
+
+		if out.Store == nil {
+			out.Store = NewMemoryStore()
+		}
+
+	default:
+		panic("csrf: unknown mode")
+	}
+
+	return out
+}
+
+type Config struct {
+	Mode           Mode
+	Secret         []byte
+	Session        func(r *http.Request) string
+	Store          Store
+	TrustedOrigins []string
+	Header         string
+	Field          string
+	Cookie         CookieConfig
+}
+
+type CookieConfig struct {
+	Name     string
+	Path     string
+	Domain   string
+	MaxAge   time.Duration
+	Insecure bool
+	SameSite http.SameSite
+}
+
+type protection struct {
+	config  Config
+	trusted map[string]bool
+}
+
+func (p *protection) token(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	cfg := p.config
+
+	if cfg.Mode == Synchronizer {
+		session := cfg.Session(r)
+
+		if session == "" {
+			return nil, nil
+		}
+
+		token, err := cfg.Store.Get(r.Context(), session)
+
+		if err != nil || token != nil {
+			return token, err
+		}
+
+		token = newToken()
+
+		return token, cfg.Store.Set(r.Context(), session, token)
+	}
+
+	session := ""
+
+	if cfg.Session != nil {
+		session = cfg.Session(r)
+	}
+
+	cookie, err := r.Cookie(cfg.Cookie.Name)
+
+	if err == nil {
+		token, err := verify(cfg.Secret, cookie.Value, session)
+
+		if err == nil {
+			return token, nil
+		}
+	}
+
+	token := newToken()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cfg.Cookie.Name,
+		Value:    sign(cfg.Secret, token, session),
+		Path:     cfg.Cookie.Path,
+		Domain:   cfg.Cookie.Domain,
+		MaxAge:   int(cfg.Cookie.MaxAge.Seconds()),
+		Secure:   !cfg.Cookie.Insecure,
+		HttpOnly: true,
+		SameSite: cfg.Cookie.SameSite,
+	})
+
+	return token, nil
+}
+
+func (p *protection) check(r *http.Request, token []byte) error {
+	err := p.checkOrigin(r)
+
+	if err != nil {
+		return err
+	}
+
+	submitted := r.Header.Get(p.config.Header)
+
+	if submitted == "" {
+		submitted = r.PostFormValue(p.config.Field)
+	}
+
+	if submitted == "" || token == nil {
+		return ErrMissingToken
+	}
+
+	got, err := unmask(submitted)
+
+	if err != nil || subtle.ConstantTimeCompare(got, token) != 1 {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	ErrCrossOrigin = &h.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: "cross origin request",
+	}
+
+	ErrMissingToken = &h.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: "missing csrf token",
+	}
+
+	ErrInvalidToken = &h.HTTPError{
+		Code:    http.StatusForbidden,
+		Message: "invalid csrf token",
+	}
+)
+
+const (
+	exemptKey = "http_csrf_exempt"
+	tokenKey  = "http_csrf_token"
+)
@@ -0,0 +1,340 @@
+package csrf
+
+import (
+	h "github.com/aakash-rajur/http"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNew_DoubleSubmit(t *testing.T) {
+	t.Parallel()
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{
+		Secret:         []byte("secret"),
+		TrustedOrigins: []string{"https://app.example.com/"},
+	}))
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}
+
+	router.GetFunc("/form", ok)
+
+	router.PostFunc("/books", ok)
+
+	router.PostFunc("/webhooks", ok, Exempt())
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com/form", nil))
+
+	cookies := w.Result().Cookies()
+
+	assert.Equalf(t, 1, len(cookies), "want %v, got %v", 1, len(cookies))
+
+	cookie := cookies[0]
+
+	assert.Truef(t, cookie.HttpOnly && cookie.Secure, "want secure http only cookie, got %v", cookie)
+
+	token := w.Header().Get("X-CSRF-Token")
+
+	assert.NotEmptyf(t, token, "want token, got %v", token)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		headers    map[string]string
+		form       url.Values
+		cookie     *http.Cookie
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should accept the header token",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token},
+			cookie:     cookie,
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "should accept the form token",
+			method:     http.MethodPost,
+			target:     "/books",
+			form:       url.Values{"csrf_token": {token}},
+			cookie:     cookie,
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "should accept same origin requests",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token, "Origin": "https://example.com", "Sec-Fetch-Site": "same-origin"},
+			cookie:     cookie,
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "should accept trusted origins",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token, "Origin": "https://app.example.com", "Sec-Fetch-Site": "same-site"},
+			cookie:     cookie,
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "should reject missing tokens",
+			method:     http.MethodPost,
+			target:     "/books",
+			cookie:     cookie,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"missing csrf token","instance":"/books","status":403,"title":"Forbidden"}`,
+		},
+		{
+			name:       "should reject tokens without cookie",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token},
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"invalid csrf token","instance":"/books","status":403,"title":"Forbidden"}`,
+		},
+		{
+			name:       "should reject forged cookies",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token},
+			cookie:     &http.Cookie{Name: "_csrf", Value: strings.Split(cookie.Value, ".")[0] + ".forged"},
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"invalid csrf token","instance":"/books","status":403,"title":"Forbidden"}`,
+		},
+		{
+			name:       "should reject cross origin requests",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token, "Origin": "https://evil.example.org"},
+			cookie:     cookie,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"cross origin request","instance":"/books","status":403,"title":"Forbidden"}`,
+		},
+		{
+			name:       "should reject cross origin referers",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token, "Referer": "https://evil.example.org/page"},
+			cookie:     cookie,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"cross origin request","instance":"/books","status":403,"title":"Forbidden"}`,
+		},
+		{
+			name:       "should reject cross site fetches",
+			method:     http.MethodPost,
+			target:     "/books",
+			headers:    map[string]string{"X-CSRF-Token": token, "Sec-Fetch-Site": "cross-site"},
+			cookie:     cookie,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"cross origin request","instance":"/books","status":403,"title":"Forbidden"}`,
+		},
+		{
+			name:       "should exempt safe methods",
+			method:     http.MethodGet,
+			target:     "/form",
+			headers:    map[string]string{"Sec-Fetch-Site": "cross-site"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "should skip exempt routes",
+			method:     http.MethodPost,
+			target:     "/webhooks",
+			headers:    map[string]string{"Origin": "https://payments.example.net"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		var r *http.Request
+
+		if tt.form != nil {
+			r = httptest.NewRequest(tt.method, "https://example.com"+tt.target, strings.NewReader(tt.form.Encode()))
+
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			r = httptest.NewRequest(tt.method, "https://example.com"+tt.target, nil)
+		}
+
+		for key, value := range tt.headers {
+			r.Header.Set(key, value)
+		}
+
+		if tt.cookie != nil {
+			r.AddCookie(tt.cookie)
+		}
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, r)
+
+		assert.Equalf(t, tt.wantStatus, w.Code, "%s: want %v, got %v", tt.name, tt.wantStatus, w.Code)
+
+		if strings.HasPrefix(tt.wantBody, "{") {
+			assert.JSONEqf(t, tt.wantBody, w.Body.String(), "%s: want %v, got %v", tt.name, tt.wantBody, w.Body.String())
+		} else {
+			assert.Equalf(t, tt.wantBody, w.Body.String(), "%s: want %v, got %v", tt.name, tt.wantBody, w.Body.String())
+		}
+	}
+}
+
+func TestNew_DoubleSubmitSession(t *testing.T) {
+	t.Parallel()
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{
+		Secret: []byte("secret"),
+		Session: func(r *http.Request) string {
+			return r.Header.Get("X-Session")
+		},
+	}))
+
+	router.GetFunc("/form", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(Token(r)))
+	})
+
+	router.PostFunc("/books", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/form", nil)
+
+	r.Header.Set("X-Session", "alice")
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	cookie, token := w.Result().Cookies()[0], w.Body.String()
+
+	tests := []struct {
+		name       string
+		session    string
+		wantStatus int
+	}{
+		{name: "should accept the cookie of the same session", session: "alice", wantStatus: http.StatusOK},
+		{name: "should reject the cookie of another session", session: "bob", wantStatus: http.StatusForbidden},
+		{name: "should reject the cookie without a session", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/books", nil)
+
+		r.Header.Set("X-Session", tt.session)
+
+		r.Header.Set("X-CSRF-Token", token)
+
+		r.AddCookie(cookie)
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, r)
+
+		assert.Equalf(t, tt.wantStatus, w.Code, "%s: want %v, got %v", tt.name, tt.wantStatus, w.Code)
+	}
+}
+
+func TestNew_Synchronizer(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{
+		Mode:  Synchronizer,
+		Store: store,
+		Session: func(r *http.Request) string {
+			return r.Header.Get("X-Session")
+		},
+	}))
+
+	router.GetFunc("/form", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(Token(r)))
+	})
+
+	router.PostFunc("/books", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	form := func(session string) string {
+		r := httptest.NewRequest(http.MethodGet, "/form", nil)
+
+		r.Header.Set("X-Session", session)
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, r)
+
+		assert.Emptyf(t, w.Result().Cookies(), "want no cookies, got %v", w.Result().Cookies())
+
+		return w.Body.String()
+	}
+
+	alice, bob := form("alice"), form("bob")
+
+	assert.NotEqualf(t, alice, form("alice"), "want masked tokens to differ, got %v", alice)
+
+	tests := []struct {
+		name       string
+		session    string
+		token      string
+		wantStatus int
+	}{
+		{name: "should accept the session token", session: "alice", token: alice, wantStatus: http.StatusOK},
+		{name: "should accept a remasked session token", session: "alice", token: form("alice"), wantStatus: http.StatusOK},
+		{name: "should reject tokens of other sessions", session: "alice", token: bob, wantStatus: http.StatusForbidden},
+		{name: "should reject requests without session", token: alice, wantStatus: http.StatusForbidden},
+		{name: "should reject malformed tokens", session: "bob", token: "abc", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/books", nil)
+
+		r.Header.Set("X-Session", tt.session)
+
+		r.Header.Set("X-CSRF-Token", tt.token)
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, r)
+
+		assert.Equalf(t, tt.wantStatus, w.Code, "%s: want %v, got %v", tt.name, tt.wantStatus, w.Code)
+	}
+
+	store.Delete("alice")
+
+	r := httptest.NewRequest(http.MethodPost, "/books", nil)
+
+	r.Header.Set("X-Session", "alice")
+
+	r.Header.Set("X-CSRF-Token", alice)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assert.Equalf(t, http.StatusForbidden, w.Code, "want %v, got %v", http.StatusForbidden, w.Code)
+
+	assert.PanicsWithValue(t, "csrf: synchronizer mode requires Session", func() {
+		New(Config{Mode: Synchronizer})
+	})
+}
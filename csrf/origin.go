@@ -0,0 +1,70 @@
+package csrf
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (p *protection) checkOrigin(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+
+	if origin == "" {
+		origin = refererOrigin(r.Header.Get("Referer"))
+	}
+
+	if origin != "" {
+		if p.trustedOrigin(r, origin) {
+			return nil
+		}
+
+		return ErrCrossOrigin
+	}
+
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "cross-site", "same-site":
+		return ErrCrossOrigin
+	default:
+		return nil
+	}
+}
+
+func (p *protection) trustedOrigin(r *http.Request, origin string) bool {
+	origin = strings.ToLower(origin)
+
+	if p.trusted[origin] {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	return u.Host == strings.ToLower(r.Host)
+}
+
+func trustedOrigins(origins []string) map[string]bool {
+	trusted := make(map[string]bool, len(origins))
+
+	for _, origin := range origins {
+		trusted[strings.ToLower(strings.TrimRight(origin, "/"))] = true
+	}
+
+	return trusted
+}
+
+func refererOrigin(referer string) string {
+	if referer == "" {
+		return ""
+	}
+
+	u, err := url.Parse(referer)
+
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "null"
+	}
+
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,46 @@
+package csrf
+
+import (
+	"context"
+	"sync"
+)
+
+type Store interface {
+	Get(ctx context.Context, session string) ([]byte, error)
+	Set(ctx context.Context, session string, token []byte) error
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string][]byte)}
+}
+
+type MemoryStore struct {
+	mu     sync.RWMutex
+	tokens map[string][]byte
+}
+
+func (store *MemoryStore) Get(ctx context.Context, session string) ([]byte, error) {
+	store.mu.RLock()
+
+	defer store.mu.RUnlock()
+
+	return store.tokens[session], nil
+}
+
+func (store *MemoryStore) Set(ctx context.Context, session string, token []byte) error {
+	store.mu.Lock()
+
+	defer store.mu.Unlock()
+
+	store.tokens[session] = token
+
+	return nil
+}
+
+func (store *MemoryStore) Delete(session string) {
+	store.mu.Lock()
+
+	defer store.mu.Unlock()
+
+	delete(store.tokens, session)
+}
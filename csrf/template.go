@@ -0,0 +1,49 @@
+package csrf
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+type tokenContext struct {
+	token []byte
+	field string
+}
+
+func Token(r *http.Request) string {
+	tc, ok := r.Context().Value(tokenKey).(*tokenContext)
+
+	if !ok {
+		return ""
+	}
+
+	return mask(tc.token)
+}
+
+func TemplateField(r *http.Request) template.HTML {
+	tc, ok := r.Context().Value(tokenKey).(*tokenContext)
+
+	if !ok {
+		return ""
+	}
+
+	field := fmt.Sprintf(
+		`<input type="hidden" name="%s" value="%s">`,
+		template.HTMLEscapeString(tc.field),
+		mask(tc.token),
+	)
+
+	return template.HTML(field)
+}
+
+func TemplateFuncs(r *http.Request) template.FuncMap {
+	return template.FuncMap{
+		"csrfToken": func() string {
+			return Token(r)
+		},
+		"csrfField": func() template.HTML {
+			return TemplateField(r)
+		},
+	}
+}
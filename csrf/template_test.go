@@ -0,0 +1,79 @@
+package csrf
+
+import (
+	h "github.com/aakash-rajur/http"
+	"github.com/stretchr/testify/assert"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	router := h.NewRouter()
+
+	router.Use(New(Config{Field: "_token"}))
+
+	router.GetFunc("/form", func(w http.ResponseWriter, r *http.Request) {
+		page := template.Must(template.New("form").Funcs(TemplateFuncs(r)).Parse(
+			`<form method="post">{{ csrfField }}</form>|{{ csrfToken }}`,
+		))
+
+		_ = page.Execute(w, nil)
+	})
+
+	router.PostFunc("/form", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/form", nil))
+
+	pattern := regexp.MustCompile(`^<form method="post"><input type="hidden" name="_token" value="([A-Za-z0-9_-]{86})"></form>\|([A-Za-z0-9_-]{86})$`)
+
+	matches := pattern.FindStringSubmatch(w.Body.String())
+
+	assert.Equalf(t, 3, len(matches), "want form with token, got %v", w.Body.String())
+
+	if len(matches) != 3 {
+		return
+	}
+
+	submissions := []struct {
+		name   string
+		body   string
+		header string
+	}{
+		{name: "should accept the rendered field", body: "_token=" + matches[1]},
+		{name: "should accept the rendered token", header: matches[2]},
+	}
+
+	for _, submission := range submissions {
+		r := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader(submission.body))
+
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		r.Header.Set("X-CSRF-Token", submission.header)
+
+		for _, cookie := range w.Result().Cookies() {
+			r.AddCookie(cookie)
+		}
+
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, r)
+
+		assert.Equalf(t, http.StatusNoContent, rec.Code, "%s: want %v, got %v", submission.name, http.StatusNoContent, rec.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.Equalf(t, "", Token(r), "want empty token, got %v", Token(r))
+
+	assert.Equalf(t, template.HTML(""), TemplateField(r), "want empty field, got %v", TemplateField(r))
+}
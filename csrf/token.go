@@ -0,0 +1,95 @@
+package csrf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+func newToken() []byte {
+	token := make([]byte, tokenLength)
+
+	_, err := rand.Read(token)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return token
+}
+
+func mask(token []byte) string {
+	masked := make([]byte, 2*tokenLength)
+
+	pad, cipher := masked[:tokenLength], masked[tokenLength:]
+
+	_, err := rand.Read(pad)
+
+	if err != nil {
+		panic(err)
+	}
+
+	subtle.XORBytes(cipher, pad, token)
+
+	return base64.RawURLEncoding.EncodeToString(masked)
+}
+
+func unmask(value string) ([]byte, error) {
+	masked, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil || len(masked) != 2*tokenLength {
+		return nil, errMalformedToken
+	}
+
+	token := make([]byte, tokenLength)
+
+	subtle.XORBytes(token, masked[:tokenLength], masked[tokenLength:])
+
+	return token, nil
+}
+
+func sign(secret, token []byte, session string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	return encode(token) + "." + encode(signature(secret, token, session))
+}
+
+func verify(secret []byte, value, session string) ([]byte, error) {
+	encoded, encodedSignature, ok := strings.Cut(value, ".")
+
+	if !ok {
+		return nil, errMalformedToken
+	}
+
+	token, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil || len(token) != tokenLength {
+		return nil, errMalformedToken
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+
+	if err != nil || !hmac.Equal(got, signature(secret, token, session)) {
+		return nil, errMalformedToken
+	}
+
+	return token, nil
+}
+
+func signature(secret, token []byte, session string) []byte {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write(token)
+
+	mac.Write([]byte(session))
+
+	return mac.Sum(nil)
+}
+
+var errMalformedToken = errors.New("csrf: malformed token")
+
+const tokenLength = 32
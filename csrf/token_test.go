@@ -0,0 +1,60 @@
+package csrf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_mask(t *testing.T) {
+	t.Parallel()
+
+	token := newToken()
+
+	first, second := mask(token), mask(token)
+
+	assert.NotEqualf(t, first, second, "want different masks, got %v", first)
+
+	for _, masked := range []string{first, second} {
+		got, err := unmask(masked)
+
+		assert.NoError(t, err)
+
+		assert.Equalf(t, token, got, "want %v, got %v", token, got)
+	}
+
+	_, err := unmask("short")
+
+	assert.ErrorIs(t, err, errMalformedToken)
+}
+
+func Test_sign(t *testing.T) {
+	t.Parallel()
+
+	token := newToken()
+
+	signed := sign([]byte("secret"), token, "alice")
+
+	tests := []struct {
+		name    string
+		secret  string
+		value   string
+		session string
+		wantErr error
+	}{
+		{name: "should verify signed tokens", secret: "secret", value: signed, session: "alice"},
+		{name: "should reject other secrets", secret: "other", value: signed, session: "alice", wantErr: errMalformedToken},
+		{name: "should reject other sessions", secret: "secret", value: signed, session: "bob", wantErr: errMalformedToken},
+		{name: "should reject unsigned tokens", secret: "secret", value: "token", session: "alice", wantErr: errMalformedToken},
+		{name: "should reject truncated signatures", secret: "secret", value: signed[:len(signed)-2], session: "alice", wantErr: errMalformedToken},
+	}
+
+	for _, tt := range tests {
+		got, err := verify([]byte(tt.secret), tt.value, tt.session)
+
+		assert.ErrorIsf(t, err, tt.wantErr, "%s: want %v, got %v", tt.name, tt.wantErr, err)
+
+		if tt.wantErr == nil {
+			assert.Equalf(t, token, got, "%s: want %v, got %v", tt.name, token, got)
+		}
+	}
+}
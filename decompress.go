@@ -0,0 +1,230 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"github.com/aakash-rajur/http/problem"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func Decompress(config DecompressConfig) Middleware {
+	cfg := saneDecompressConfig(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		encodings := contentEncodings(r.Header.Get("Content-Encoding"))
+
+		raw, _ := routeValue[bool](r, rawBodyKey)
+
+		if len(encodings) == 0 || raw || r.Body == nil || r.Body == http.NoBody {
+			next(r)
+
+			return
+		}
+
+		for _, encoding := range encodings {
+			if !supportedContentEncoding(encoding) {
+				w.Header().Set("Accept-Encoding", "gzip, deflate")
+
+				detail := fmt.Sprintf("unsupported content encoding %q", encoding)
+
+				RenderError(w, r, problem.New(http.StatusUnsupportedMediaType, detail))
+
+				return
+			}
+		}
+
+		body, err := newDecompressReader(cfg, r.Body, encodings)
+
+		if err != nil {
+			RenderError(w, r, problem.New(http.StatusBadRequest, "malformed "+encodings[len(encodings)-1]+" request body"))
+
+			return
+		}
+
+		limit, ok := effectiveBodyLimit(r)
+
+		if ok && limit > 0 && limit < body.config.MaxSize {
+			body.config.MaxSize = limit
+
+			body.tooLarge = &BodyTooLargeError{Limit: limit}
+		}
+
+		dr := new(http.Request)
+
+		*dr = *r
+
+		dr.Header = r.Header.Clone()
+
+		dr.Header.Del("Content-Encoding")
+
+		dr.Header.Del("Content-Length")
+
+		dr.ContentLength = -1
+
+		dr.Body = body
+
+		next(dr)
+
+		if body.err == nil {
+			return
+		}
+
+		rw, ok := UnwrapResponseWriter(w)
+
+		if ok && rw.StatusCode == 0 {
+			HandleError(w, dr, body.err)
+		}
+	}
+}
+
+func WithRawBody() RouteOption {
+	return WithMetadata(rawBodyKey, true)
+}
+
+func saneDecompressConfig(in DecompressConfig) DecompressConfig {
+	out := DecompressConfig{
+		MaxSize:  10 << 20,
+		MaxRatio: 100,
+	}
+
+	if in.MaxSize > 0 {
+		out.MaxSize = in.MaxSize
+	}
+
+	if in.MaxRatio > 0 {
+		out.MaxRatio = in.MaxRatio
+	}
+
+	return out
+}
+
+func contentEncodings(header string) []string {
+	encodings := make([]string, 0)
+
+	for _, each := range strings.Split(header, ",") {
+		each = strings.ToLower(strings.TrimSpace(each))
+
+		if each == "" || each == "identity" {
+			continue
+		}
+
+		encodings = append(encodings, each)
+	}
+
+	return encodings
+}
+
+func supportedContentEncoding(encoding string) bool {
+	switch encoding {
+	case "gzip", "x-gzip", "deflate":
+		return true
+	default:
+		return false
+	}
+}
+
+type DecompressConfig struct {
+	MaxSize  int64
+	MaxRatio float64
+}
+
+type decompressReader struct {
+	config     DecompressConfig
+	body       io.ReadCloser
+	compressed *countingReader
+	reader     io.Reader
+	read       int64
+	tooLarge   error
+	err        error
+}
+
+func newDecompressReader(cfg DecompressConfig, body io.ReadCloser, encodings []string) (*decompressReader, error) {
+	compressed := &countingReader{reader: body}
+
+	var reader io.Reader = compressed
+
+	for i := len(encodings) - 1; i > -1; i -= 1 {
+		var err error
+
+		switch encodings[i] {
+		case "deflate":
+			reader, err = zlib.NewReader(reader)
+		default:
+			reader, err = gzip.NewReader(reader)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dr := &decompressReader{
+		config:     cfg,
+		body:       body,
+		compressed: compressed,
+		reader:     reader,
+		tooLarge:   ErrDecompressedTooLarge,
+	}
+
+	return dr, nil
+}
+
+func (dr *decompressReader) Read(buffer []byte) (int, error) {
+	if dr.err != nil {
+		return 0, dr.err
+	}
+
+	n, err := dr.reader.Read(buffer)
+
+	dr.read += int64(n)
+
+	if dr.read > dr.config.MaxSize {
+		dr.err = dr.tooLarge
+
+		return 0, dr.err
+	}
+
+	ratio := float64(dr.read) / float64(max(dr.compressed.read, 1))
+
+	if dr.read > minRatioCheck && ratio > dr.config.MaxRatio {
+		dr.err = ErrCompressionRatio
+
+		return 0, dr.err
+	}
+
+	return n, err
+}
+
+func (dr *decompressReader) Close() error {
+	return dr.body.Close()
+}
+
+type countingReader struct {
+	reader io.Reader
+	read   int64
+}
+
+func (cr *countingReader) Read(buffer []byte) (int, error) {
+	n, err := cr.reader.Read(buffer)
+
+	cr.read += int64(n)
+
+	return n, err
+}
+
+var ErrDecompressedTooLarge = &HTTPError{
+	Code:    http.StatusRequestEntityTooLarge,
+	Message: "decompressed request body too large",
+}
+
+var ErrCompressionRatio = &HTTPError{
+	Code:    http.StatusRequestEntityTooLarge,
+	Message: "request body compression ratio too high",
+}
+
+const minRatioCheck = 64 << 10
+
+const rawBodyKey = "http_raw_body"
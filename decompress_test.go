@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecompress(t *testing.T) {
+	t.Parallel()
+
+	payload := `name=Alchemist&format=paperback`
+
+	tests := []struct {
+		name       string
+		config     DecompressConfig
+		target     string
+		encoding   string
+		body       []byte
+		wantStatus int
+		wantBody   string
+		wantHeader string
+	}{
+		{
+			name:       "should decode gzip bodies",
+			target:     "/echo",
+			encoding:   "gzip",
+			body:       compressBody("gzip", []byte(payload)),
+			wantStatus: http.StatusOK,
+			wantBody:   payload + "|-1",
+		},
+		{
+			name:       "should decode deflate bodies",
+			target:     "/echo",
+			encoding:   "deflate",
+			body:       compressBody("deflate", []byte(payload)),
+			wantStatus: http.StatusOK,
+			wantBody:   payload + "|-1",
+		},
+		{
+			name:       "should decode stacked encodings in reverse order",
+			target:     "/echo",
+			encoding:   "deflate, x-gzip",
+			body:       compressBody("gzip", compressBody("deflate", []byte(payload))),
+			wantStatus: http.StatusOK,
+			wantBody:   payload + "|-1",
+		},
+		{
+			name:       "should pass identity bodies through",
+			target:     "/echo",
+			encoding:   "identity",
+			body:       []byte(payload),
+			wantStatus: http.StatusOK,
+			wantBody:   payload + "|31",
+		},
+		{
+			name:       "should reject unsupported encodings",
+			target:     "/echo",
+			encoding:   "br",
+			body:       []byte(payload),
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantBody:   `{"detail":"unsupported content encoding \"br\"","instance":"/echo","status":415,"title":"Unsupported Media Type"}`,
+			wantHeader: "gzip, deflate",
+		},
+		{
+			name:       "should reject malformed bodies",
+			target:     "/echo",
+			encoding:   "gzip",
+			body:       []byte(payload),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"detail":"malformed gzip request body","instance":"/echo","status":400,"title":"Bad Request"}`,
+		},
+		{
+			name:       "should surface the size limit to error returning handlers",
+			config:     DecompressConfig{MaxSize: 1024},
+			target:     "/echo",
+			encoding:   "gzip",
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 4096)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"detail":"decompressed request body too large","instance":"/echo","status":413,"title":"Request Entity Too Large"}`,
+		},
+		{
+			name:       "should respond 413 when the handler ignores the limit",
+			config:     DecompressConfig{MaxSize: 1024},
+			target:     "/drain",
+			encoding:   "gzip",
+			body:       compressBody("gzip", bytes.Repeat([]byte("a"), 4096)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"detail":"decompressed request body too large","instance":"/drain","status":413,"title":"Request Entity Too Large"}`,
+		},
+		{
+			name:       "should stop compression bombs by ratio",
+			target:     "/drain",
+			encoding:   "gzip",
+			body:       compressBody("gzip", bytes.Repeat([]byte{0}, 1<<20)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"detail":"request body compression ratio too high","instance":"/drain","status":413,"title":"Request Entity Too Large"}`,
+		},
+		{
+			name:       "should leave raw routes untouched",
+			target:     "/proxy",
+			encoding:   "gzip",
+			body:       compressBody("gzip", []byte(payload)),
+			wantStatus: http.StatusOK,
+			wantBody:   "gzip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+
+			router.Use(Decompress(tt.config))
+
+			router.PostE("/echo", func(w http.ResponseWriter, r *http.Request) error {
+				body, err := io.ReadAll(r.Body)
+
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintf(w, "%s|%d", body, r.ContentLength)
+
+				return err
+			})
+
+			router.PostFunc("/drain", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+			})
+
+			router.PostFunc("/proxy", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Header.Get("Content-Encoding")))
+			}, WithRawBody())
+
+			r := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewReader(tt.body))
+
+			r.Header.Set("Content-Encoding", tt.encoding)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if strings.HasPrefix(tt.wantBody, "{") {
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			} else {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+
+			assert.Equalf(t, tt.wantHeader, w.Header().Get("Accept-Encoding"), "want %v, got %v", tt.wantHeader, w.Header().Get("Accept-Encoding"))
+		})
+	}
+}
+
+func Test_contentEncodings(t *testing.T) {
+	t.Parallel()
+
+	got := contentEncodings(" GZIP, identity,,deflate ")
+
+	want := []string{"gzip", "deflate"}
+
+	assert.Equalf(t, want, got, "want %v, got %v", want, got)
+}
+
+func compressBody(encoding string, body []byte) []byte {
+	buffer := new(bytes.Buffer)
+
+	var writer io.WriteCloser = gzip.NewWriter(buffer)
+
+	if encoding == "deflate" {
+		writer = zlib.NewWriter(buffer)
+	}
+
+	_, _ = writer.Write(body)
+
+	_ = writer.Close()
+
+	return buffer.Bytes()
+}
@@ -0,0 +1,11 @@
+package http
+
+import "github.com/aakash-rajur/http/register"
+
+type Dialect = register.Dialect
+
+const (
+	DialectBraces = register.DialectBraces
+	DialectColon  = register.DialectColon
+	DialectBoth   = register.DialectBoth
+)
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"fmt"
+	"github.com/aakash-rajur/http/register"
+	"net/http"
+	"strings"
+)
+
+func (router *Router) Explain(r *http.Request) Explanation {
+	router.mu.RLock()
+
+	defer router.mu.RUnlock()
+
+	path := pathWithMethod(r.Method, r.URL.Path)
+
+	explanation := Explanation{
+		Explanation: router.register.Explain(path),
+		Rejections:  make([]string, 0),
+	}
+
+	matches, err := router.register.FindAll(path)
+
+	if err != nil {
+		return explanation
+	}
+
+	for _, match := range matches {
+		route, ok := match.Value.(*Route)
+
+		if !ok {
+			continue
+		}
+
+		err := route.match(r)
+
+		if err == nil {
+			continue
+		}
+
+		explanation.Rejections = append(explanation.Rejections, fmt.Sprintf("%s: %s", route, err))
+	}
+
+	return explanation
+}
+
+type Explanation struct {
+	register.Explanation
+	Rejections []string `json:"rejections" yaml:"rejections"`
+}
+
+func (e Explanation) String() string {
+	lines := []string{e.Explanation.String(), "rejected by matchers:"}
+
+	if len(e.Rejections) == 0 {
+		lines = append(lines, "  none")
+	}
+
+	for _, rejection := range e.Rejections {
+		lines = append(lines, "  "+rejection)
+	}
+
+	return strings.Join(lines, "\n")
+}
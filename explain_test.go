@@ -0,0 +1,42 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_Explain(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.GetFunc("/api/v2/books", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.PostFunc(
+		"/api/v2/books",
+		func(w http.ResponseWriter, r *http.Request) {},
+		WithMatchers(MatchContentType("application/json")),
+	)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v2/books/", nil)
+
+	r.Header.Set("Content-Type", "text/csv")
+
+	got := router.Explain(r)
+
+	assert.Equalf(t, "/POST/api/v2/books", got.Path, "Explain() path = %v", got.Path)
+
+	assert.Equalf(t, []string{"/POST/api/v2/books"}, got.Matches, "Explain() matches = %v", got.Matches)
+
+	assert.Equalf(
+		t,
+		[]string{`POST /api/v2/books: unsupported media type: content-type "text/csv"`},
+		got.Rejections,
+		"Explain() rejections = %v",
+		got.Rejections,
+	)
+
+	assert.Containsf(t, got.String(), "rejected by matchers:", "String() should list rejections")
+}
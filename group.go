@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type Group struct {
+	registrar Registrar
+	prefix    string
+	opts      []RouteOption
+}
+
+func NewGroup(registrar Registrar, prefix string, opts ...RouteOption) *Group {
+	return &Group{
+		registrar: registrar,
+		prefix:    strings.TrimRight(prefix, "/"),
+		opts:      opts,
+	}
+}
+
+func (router *Router) Group(prefix string, opts ...RouteOption) *Group {
+	return NewGroup(router, prefix, opts...)
+}
+
+func (g *Group) Group(prefix string, opts ...RouteOption) *Group {
+	return NewGroup(g, prefix, opts...)
+}
+
+func (g *Group) Prefix() string {
+	parent, ok := g.registrar.(*Group)
+
+	if ok {
+		return parent.Prefix() + g.prefix
+	}
+
+	return g.prefix
+}
+
+func (g *Group) HandleMethod(method, pattern string, handler http.Handler, opts ...RouteOption) {
+	path := g.prefix + pattern
+
+	if path == "" {
+		path = "/"
+	}
+
+	g.registrar.HandleMethod(method, path, handler, append(slices.Clone(g.opts), opts...)...)
+}
+
+func (g *Group) HandleMethodFunc(method, pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	g.HandleMethod(method, pattern, handlerFunc, opts...)
+}
+
+func (g *Group) HandleMethodE(method, pattern string, handler HandlerE, opts ...RouteOption) {
+	g.HandleMethod(method, pattern, handler, opts...)
+}
+
+func (g *Group) Get(pattern string, handler http.Handler, opts ...RouteOption) {
+	g.HandleMethod(http.MethodGet, pattern, handler, opts...)
+}
+
+func (g *Group) GetFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	g.Get(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) GetE(pattern string, handler HandlerE, opts ...RouteOption) {
+	g.Get(pattern, handler, opts...)
+}
+
+func (g *Group) Post(pattern string, handler http.Handler, opts ...RouteOption) {
+	g.HandleMethod(http.MethodPost, pattern, handler, opts...)
+}
+
+func (g *Group) PostFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	g.Post(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) PostE(pattern string, handler HandlerE, opts ...RouteOption) {
+	g.Post(pattern, handler, opts...)
+}
+
+func (g *Group) Put(pattern string, handler http.Handler, opts ...RouteOption) {
+	g.HandleMethod(http.MethodPut, pattern, handler, opts...)
+}
+
+func (g *Group) PutFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	g.Put(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) PutE(pattern string, handler HandlerE, opts ...RouteOption) {
+	g.Put(pattern, handler, opts...)
+}
+
+func (g *Group) Patch(pattern string, handler http.Handler, opts ...RouteOption) {
+	g.HandleMethod(http.MethodPatch, pattern, handler, opts...)
+}
+
+func (g *Group) PatchFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	g.Patch(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) PatchE(pattern string, handler HandlerE, opts ...RouteOption) {
+	g.Patch(pattern, handler, opts...)
+}
+
+func (g *Group) Delete(pattern string, handler http.Handler, opts ...RouteOption) {
+	g.HandleMethod(http.MethodDelete, pattern, handler, opts...)
+}
+
+func (g *Group) DeleteFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	g.Delete(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) DeleteE(pattern string, handler HandlerE, opts ...RouteOption) {
+	g.Delete(pattern, handler, opts...)
+}
+
+func (g *Group) Resource(pattern string, controller any, opts ...ResourceOption) *Resource {
+	return NewResource(g, pattern, controller, opts...)
+}
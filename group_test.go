@@ -0,0 +1,94 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_Group(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	admin := router.Group("/admin/", WithMetadata("tier", "admin"), WithMetadata("audit", true))
+
+	reports := admin.Group("/reports", WithMetadata("tier", "reports"))
+
+	admin.GetFunc("", func(w http.ResponseWriter, r *http.Request) {})
+
+	admin.PostE("/users", func(w http.ResponseWriter, r *http.Request) error { return nil })
+
+	reports.GetFunc("/{id}", func(w http.ResponseWriter, r *http.Request) {}, WithName("reports.show"))
+
+	reports.GetFunc("/daily", func(w http.ResponseWriter, r *http.Request) {}, WithMetadata("tier", "daily"))
+
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		want      string
+		wantTier  any
+		wantAudit any
+	}{
+		{
+			name:      "should register the group root",
+			method:    http.MethodGet,
+			target:    "/admin",
+			want:      "GET /admin",
+			wantTier:  "admin",
+			wantAudit: true,
+		},
+		{
+			name:      "should prefix group routes",
+			method:    http.MethodPost,
+			target:    "/admin/users",
+			want:      "POST /admin/users",
+			wantTier:  "admin",
+			wantAudit: true,
+		},
+		{
+			name:      "should nest groups and override their options",
+			method:    http.MethodGet,
+			target:    "/admin/reports/7",
+			want:      "GET /admin/reports/{id}",
+			wantTier:  "reports",
+			wantAudit: true,
+		},
+		{
+			name:      "should let route options win",
+			method:    http.MethodGet,
+			target:    "/admin/reports/daily",
+			want:      "GET /admin/reports/daily",
+			wantTier:  "daily",
+			wantAudit: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := router.lookup(httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.NotNilf(t, route, "want a route for %v", tt.target)
+
+			assert.Equalf(t, tt.want, route.String(), "want %v, got %v", tt.want, route.String())
+
+			tier, _ := route.Value("tier")
+
+			assert.Equalf(t, tt.wantTier, tier, "want %v, got %v", tt.wantTier, tier)
+
+			audit, _ := route.Value("audit")
+
+			assert.Equalf(t, tt.wantAudit, audit, "want %v, got %v", tt.wantAudit, audit)
+		})
+	}
+
+	route, ok := router.NamedRoute("reports.show")
+
+	assert.Truef(t, ok, "want reports.show to be named")
+
+	assert.Equalf(t, "/admin/reports/{id}", route.Pattern, "want /admin/reports/{id}, got %v", route.Pattern)
+
+	assert.Equalf(t, "/admin/reports", reports.Prefix(), "want /admin/reports, got %v", reports.Prefix())
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type HandlerE func(w http.ResponseWriter, r *http.Request) error
+
+func (handler HandlerE) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := handler(w, r)
+
+	if err == nil {
+		return
+	}
+
+	HandleError(w, r, err)
+}
+
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	recordError(w, err)
+
+	handler := DefaultErrorHandler
+
+	router, ok := routerFromRequest(r)
+
+	if ok {
+		state := router.state.Load()
+
+		if state.errorHandler != nil {
+			handler = state.errorHandler
+		}
+	}
+
+	handler(w, r, err)
+}
+
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	rw, ok := UnwrapResponseWriter(w)
+
+	if ok && rw.StatusCode != 0 {
+		return
+	}
+
+	RenderError(w, r, err)
+}
+
+type HTTPError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func Errorf(code int, format string, args ...any) *HTTPError {
+	err := fmt.Errorf(format, args...)
+
+	return &HTTPError{
+		Code:    code,
+		Message: err.Error(),
+		Err:     errors.Unwrap(err),
+	}
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.Code)
+	}
+
+	return e.Message
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) Status() int {
+	return e.Code
+}
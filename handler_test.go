@@ -0,0 +1,225 @@
+package http
+
+import (
+	"errors"
+	"github.com/aakash-rajur/http/problem"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errOutOfStock = errors.New("out of stock")
+
+func TestHandlerE(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		handler    HandlerE
+		wantStatus int
+		wantBody   string
+		wantLog    string
+	}{
+		{
+			name: "should pass through successful responses",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				_, err := w.Write([]byte("ok"))
+
+				return err
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name: "should render typed http errors",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return Errorf(http.StatusConflict, "book %d: %w", 7, errOutOfStock)
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"detail":"book 7: out of stock","instance":"/books/7","status":409,"title":"Conflict"}`,
+			wantLog:    "| book 7: out of stock",
+		},
+		{
+			name: "should hide unhandled errors",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return errors.New("dial tcp 10.0.0.1:5432: connection refused")
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"instance":"/books/7","status":500,"title":"Internal Server Error"}`,
+			wantLog:    "| dial tcp 10.0.0.1:5432: connection refused",
+		},
+		{
+			name: "should not render after the response started",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusAccepted)
+
+				return errors.New("stream interrupted")
+			},
+			wantStatus: http.StatusAccepted,
+			wantBody:   "",
+			wantLog:    "| stream interrupted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := NewMemoryWriter()
+
+			router := NewRouter()
+
+			router.Use(Logger(LoggerConfig{Output: output}))
+
+			router.GetE("/books/{id}", tt.handler)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/7", nil))
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if strings.HasPrefix(tt.wantBody, "{") {
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			} else {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+
+			if tt.wantLog == "" {
+				assert.NotContainsf(t, output.Content, "/books/7 |", "want no error logged, got %v", output.Content)
+
+				return
+			}
+
+			assert.Containsf(t, output.Content, tt.wantLog, "want %v, got %v", tt.wantLog, output.Content)
+		})
+	}
+}
+
+func TestRouter_ErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.ErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		if errors.Is(err, errOutOfStock) {
+			RenderError(w, r, problem.New(http.StatusGone, "sold out"))
+
+			return
+		}
+
+		DefaultErrorHandler(w, r, err)
+	})
+
+	router.PostE("/orders", func(w http.ResponseWriter, r *http.Request) error {
+		return errOutOfStock
+	})
+
+	router.DeleteE("/orders", func(w http.ResponseWriter, r *http.Request) error {
+		return Errorf(http.StatusForbidden, "")
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should map errors through the hook",
+			method:     http.MethodPost,
+			wantStatus: http.StatusGone,
+			wantBody:   `{"detail":"sold out","instance":"/orders","status":410,"title":"Gone"}`,
+		},
+		{
+			name:       "should fall back to the default handler",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"detail":"Forbidden","instance":"/orders","status":403,"title":"Forbidden"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/orders", nil))
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandleError_PendingRegistration(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	started, registering := make(chan struct{}), make(chan struct{})
+
+	router.GetE("/books", func(w http.ResponseWriter, r *http.Request) error {
+		close(started)
+
+		<-registering
+
+		time.Sleep(20 * time.Millisecond)
+
+		return errOutOfStock
+	})
+
+	go func() {
+		<-started
+
+		close(registering)
+
+		router.GetFunc("/authors", func(w http.ResponseWriter, r *http.Request) {})
+	}()
+
+	done := make(chan int)
+
+	go func() {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books", nil))
+
+		done <- w.Code
+	}()
+
+	select {
+	case got := <-done:
+		assert.Equalf(t, http.StatusInternalServerError, got, "want %v, got %v", http.StatusInternalServerError, got)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("want the error rendered while a registration is pending, got a deadlock")
+	}
+}
+
+func TestHandlerE_WithoutRouter(t *testing.T) {
+	t.Parallel()
+
+	handler := HandlerE(func(w http.ResponseWriter, r *http.Request) error {
+		return Errorf(http.StatusTeapot, "short and stout")
+	})
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equalf(t, http.StatusTeapot, w.Code, "want %v, got %v", http.StatusTeapot, w.Code)
+
+	assert.Equalf(t, problem.MediaTypeJSON, w.Header().Get("Content-Type"), "want %v, got %v", problem.MediaTypeJSON, w.Header().Get("Content-Type"))
+}
+
+func TestErrorf(t *testing.T) {
+	t.Parallel()
+
+	err := Errorf(http.StatusNotFound, "book %q: %w", "dune", errOutOfStock)
+
+	assert.Equalf(t, `book "dune": out of stock`, err.Error(), "want %v, got %v", `book "dune": out of stock`, err.Error())
+
+	assert.Truef(t, errors.Is(err, errOutOfStock), "want errors.Is to see the wrapped error")
+
+	assert.Equalf(t, http.StatusNotFound, err.Status(), "want %v, got %v", http.StatusNotFound, err.Status())
+}
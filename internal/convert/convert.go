@@ -0,0 +1,135 @@
+package convert
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+func Parse[T any](raw string) (T, error) {
+	var value T
+
+	err := Set(reflect.ValueOf(&value).Elem(), raw)
+
+	return value, err
+}
+
+func Set(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return Set(v.Elem(), raw)
+	}
+
+	if v.CanAddr() {
+		unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+
+		if ok {
+			return wrap(raw, v.Type(), unmarshaler.UnmarshalText([]byte(raw)))
+		}
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+
+		if err != nil {
+			return wrap(raw, v.Type(), err)
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+
+		if err != nil {
+			return wrap(raw, v.Type(), err)
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+
+		if err != nil {
+			return wrap(raw, v.Type(), err)
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+
+		if err != nil {
+			return wrap(raw, v.Type(), err)
+		}
+
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+
+		if err != nil {
+			return wrap(raw, v.Type(), err)
+		}
+
+		v.SetFloat(f)
+	case reflect.Slice:
+		item := reflect.New(v.Type().Elem()).Elem()
+
+		err := Set(item, raw)
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.Append(v, item))
+	default:
+		return fmt.Errorf("cannot convert %q to %s: %w", raw, v.Type(), ErrUnsupported)
+	}
+
+	return nil
+}
+
+func SetAll(v reflect.Value, raws []string) error {
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		if len(raws) == 0 {
+			return nil
+		}
+
+		return Set(v, raws[0])
+	}
+
+	for _, raw := range raws {
+		err := Set(v, raw)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func wrap(raw string, t reflect.Type, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ne, ok := err.(*strconv.NumError); ok {
+		err = ne.Err
+	}
+
+	return fmt.Errorf("cannot convert %q to %s: %w", raw, t, err)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+var ErrUnsupported = errors.New("unsupported type")
@@ -0,0 +1,93 @@
+package convert
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	type target struct {
+		String   string
+		Bool     bool
+		Int      int
+		Int8     int8
+		Uint     uint
+		Float    float64
+		Duration time.Duration
+		Time     time.Time
+		Addr     netip.Addr
+		Pointer  *int
+		Slice    []int
+	}
+
+	tests := []struct {
+		name    string
+		field   string
+		raw     string
+		want    any
+		wantErr string
+	}{
+		{name: "string", field: "String", raw: "hello", want: "hello"},
+		{name: "bool", field: "Bool", raw: "true", want: true},
+		{name: "int", field: "Int", raw: "-42", want: -42},
+		{name: "int8 overflow", field: "Int8", raw: "300", wantErr: `cannot convert "300" to int8: value out of range`},
+		{name: "uint", field: "Uint", raw: "7", want: uint(7)},
+		{name: "float", field: "Float", raw: "1.5", want: 1.5},
+		{name: "duration", field: "Duration", raw: "1m30s", want: 90 * time.Second},
+		{name: "time", field: "Time", raw: "2024-01-07T14:21:33Z", want: time.Date(2024, 1, 7, 14, 21, 33, 0, time.UTC)},
+		{name: "text unmarshaler", field: "Addr", raw: "127.0.0.1", want: netip.MustParseAddr("127.0.0.1")},
+		{name: "pointer", field: "Pointer", raw: "5", want: 5},
+		{name: "slice", field: "Slice", raw: "5", want: []int{5}},
+		{name: "invalid int", field: "Int", raw: "abc", wantErr: `cannot convert "abc" to int: invalid syntax`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := target{}
+
+			field := reflect.ValueOf(&dst).Elem().FieldByName(tt.field)
+
+			err := Set(field, tt.raw)
+
+			if tt.wantErr != "" {
+				assert.EqualErrorf(t, err, tt.wantErr, "Set() err = %v, want %v", err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoErrorf(t, err, "Set() err = %v", err)
+
+			got := field.Interface()
+
+			if field.Kind() == reflect.Pointer {
+				got = field.Elem().Interface()
+			}
+
+			assert.Equalf(t, tt.want, got, "Set() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	got, err := Parse[int64]("10")
+
+	assert.NoErrorf(t, err, "Parse() err = %v", err)
+
+	assert.Equalf(t, int64(10), got, "Parse() = %v, want 10", got)
+
+	_, err = Parse[int64]("ten")
+
+	assert.ErrorIsf(t, err, strconv.ErrSyntax, "Parse() err = %v, want %v", err, strconv.ErrSyntax)
+
+	_, err = Parse[map[string]string]("x")
+
+	assert.ErrorIsf(t, err, ErrUnsupported, "Parse() err = %v, want %v", err, ErrUnsupported)
+}
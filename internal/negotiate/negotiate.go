@@ -0,0 +1,220 @@
+package negotiate
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Spec struct {
+	Value   string
+	Params  map[string]string
+	Quality float64
+}
+
+func Parse(header string) []Spec {
+	specs := make([]Spec, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+
+		if part == "" {
+			continue
+		}
+
+		spec := Spec{
+			Params:  make(map[string]string),
+			Quality: 1,
+		}
+
+		pieces := strings.Split(part, ";")
+
+		spec.Value = strings.ToLower(strings.TrimSpace(pieces[0]))
+
+		for _, piece := range pieces[1:] {
+			key, value, ok := strings.Cut(piece, "=")
+
+			if !ok {
+				continue
+			}
+
+			key = strings.ToLower(strings.TrimSpace(key))
+
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+
+			if key != "q" {
+				spec.Params[key] = value
+
+				continue
+			}
+
+			quality, err := strconv.ParseFloat(value, 64)
+
+			if err != nil || quality < 0 || quality > 1 {
+				quality = 0
+			}
+
+			spec.Quality = quality
+		}
+
+		specs = append(specs, spec)
+	}
+
+	slices.SortStableFunc(
+		specs,
+		func(a, b Spec) int {
+			if a.Quality > b.Quality {
+				return -1
+			}
+
+			if a.Quality < b.Quality {
+				return 1
+			}
+
+			return specificity(b.Value) - specificity(a.Value)
+		},
+	)
+
+	return specs
+}
+
+func MediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	return mediaType
+}
+
+func MediaMatches(pattern, mediaType string) bool {
+	pattern, mediaType = MediaType(pattern), MediaType(mediaType)
+
+	if pattern == "*/*" || pattern == "*" || mediaType == "*/*" {
+		return true
+	}
+
+	if pattern == mediaType {
+		return true
+	}
+
+	pt, ps, ok := strings.Cut(pattern, "/")
+
+	if !ok {
+		return false
+	}
+
+	mt, ms, ok := strings.Cut(mediaType, "/")
+
+	if !ok || pt != mt {
+		return false
+	}
+
+	return ps == "*" || ms == "*"
+}
+
+func Media(accept string, offers []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		accept = "*/*"
+	}
+
+	return best(Parse(accept), offers, MediaMatches)
+}
+
+func Encoding(acceptEncoding string, offers []string) (string, bool) {
+	specs := Parse(acceptEncoding)
+
+	if len(specs) == 0 {
+		return "identity", true
+	}
+
+	offers = append(slices.Clone(offers), "identity")
+
+	equals := func(pattern, offer string) bool {
+		return pattern == "*" || strings.EqualFold(pattern, offer)
+	}
+
+	chosen, ok := best(specs, offers, equals)
+
+	if ok {
+		return chosen, true
+	}
+
+	quality, _ := qualityOf(specs, "identity", equals)
+
+	return "identity", quality < 0
+}
+
+func Accepts(accept string, offer string) bool {
+	_, ok := Media(accept, []string{offer})
+
+	return ok
+}
+
+func best(specs []Spec, offers []string, matches func(pattern, offer string) bool) (string, bool) {
+	chosen, chosenQuality, chosenSpecificity := "", 0.0, -1
+
+	for _, offer := range offers {
+		quality, specificity := qualityOf(specs, offer, matches)
+
+		if quality <= 0 {
+			continue
+		}
+
+		if quality > chosenQuality || (quality == chosenQuality && specificity > chosenSpecificity) {
+			chosen, chosenQuality, chosenSpecificity = offer, quality, specificity
+		}
+	}
+
+	return chosen, chosen != ""
+}
+
+func qualityOf(specs []Spec, offer string, matches func(pattern, offer string) bool) (float64, int) {
+	quality, mostSpecific := -1.0, -1
+
+	for _, spec := range specs {
+		if !matches(spec.Value, offer) {
+			continue
+		}
+
+		s := specificity(spec.Value)
+
+		if s <= mostSpecific {
+			continue
+		}
+
+		quality, mostSpecific = spec.Quality, s
+	}
+
+	return quality, mostSpecific
+}
+
+func specificity(value string) int {
+	switch {
+	case value == "*" || value == "*/*":
+		return 0
+	case strings.HasSuffix(value, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func AddVary(header http.Header, values ...string) {
+	existing := strings.Join(header.Values("Vary"), ",")
+
+	for _, value := range values {
+		present := slices.ContainsFunc(strings.Split(existing, ","), func(each string) bool {
+			return strings.EqualFold(strings.TrimSpace(each), value)
+		})
+
+		if !present {
+			header.Add("Vary", value)
+
+			existing += "," + value
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package negotiate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	got := Parse("text/*;q=0.5, application/json, */*;q=0.1, text/html;level=1")
+
+	want := []Spec{
+		{Value: "application/json", Params: map[string]string{}, Quality: 1},
+		{Value: "text/html", Params: map[string]string{"level": "1"}, Quality: 1},
+		{Value: "text/*", Params: map[string]string{}, Quality: 0.5},
+		{Value: "*/*", Params: map[string]string{}, Quality: 0.1},
+	}
+
+	assert.Equalf(t, want, got, "Parse() = %v, want %v", got, want)
+}
+
+func TestMedia(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		accept string
+		offers []string
+		want   string
+		ok     bool
+	}{
+		{
+			name:   "should pick first offer when accept is empty",
+			accept: "",
+			offers: []string{"application/json", "application/xml"},
+			want:   "application/json",
+			ok:     true,
+		},
+		{
+			name:   "should honour q-values",
+			accept: "application/json;q=0.5, application/xml",
+			offers: []string{"application/json", "application/xml"},
+			want:   "application/xml",
+			ok:     true,
+		},
+		{
+			name:   "should match wildcard subtype",
+			accept: "text/*",
+			offers: []string{"application/json", "text/csv"},
+			want:   "text/csv",
+			ok:     true,
+		},
+		{
+			name:   "should reject q=0",
+			accept: "application/json;q=0, text/*",
+			offers: []string{"application/json"},
+			want:   "",
+			ok:     false,
+		},
+		{
+			name:   "should prefer specific over wildcard",
+			accept: "*/*;q=0.1, application/xml;q=0.9",
+			offers: []string{"application/json", "application/xml"},
+			want:   "application/xml",
+			ok:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Media(tt.accept, tt.offers)
+
+			assert.Equalf(t, tt.want, got, "Media() = %v, want %v", got, tt.want)
+
+			assert.Equalf(t, tt.ok, ok, "Media() ok = %v, want %v", ok, tt.ok)
+		})
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+		ok             bool
+	}{
+		{
+			name:           "should fall back to identity when header is absent",
+			acceptEncoding: "",
+			want:           "identity",
+			ok:             true,
+		},
+		{
+			name:           "should pick highest quality",
+			acceptEncoding: "gzip;q=0.5, deflate",
+			want:           "deflate",
+			ok:             true,
+		},
+		{
+			name:           "should honour wildcard",
+			acceptEncoding: "*",
+			want:           "gzip",
+			ok:             true,
+		},
+		{
+			name:           "should allow identity unless excluded",
+			acceptEncoding: "br",
+			want:           "identity",
+			ok:             true,
+		},
+		{
+			name:           "should fail when identity is excluded",
+			acceptEncoding: "br, identity;q=0",
+			want:           "identity",
+			ok:             false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Encoding(tt.acceptEncoding, []string{"gzip", "deflate"})
+
+			assert.Equalf(t, tt.want, got, "Encoding() = %v, want %v", got, tt.want)
+
+			assert.Equalf(t, tt.ok, ok, "Encoding() ok = %v, want %v", ok, tt.ok)
+		})
+	}
+}
+
+func TestAddVary(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+
+	header.Set("Vary", "Accept, origin")
+
+	AddVary(header, "Origin", "Access-Control-Request-Method", "Access-Control-Request-Method")
+
+	got := strings.Join(header.Values("Vary"), ", ")
+
+	want := "Accept, origin, Access-Control-Request-Method"
+
+	assert.Equalf(t, want, got, "want %v, got %v", want, got)
+}
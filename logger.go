@@ -47,14 +47,28 @@ func log(
 
 	end := time.Now()
 
-	statusCode := 0
+	statusCode, errorMessage, requestId := 0, "", ""
 
-	hw, ok := w.(*ResponseWriter)
+	hw, ok := UnwrapResponseWriter(w)
 
 	if ok {
 		statusCode = hw.StatusCode
 	}
 
+	if ok && hw.Err != nil {
+		errorMessage = hw.Err.Error()
+	}
+
+	if ok {
+		requestId = hw.RequestID
+	}
+
+	id, found := RequestIDFromRequest(r)
+
+	if found {
+		requestId = id
+	}
+
 	clientIps := make([]string, 0)
 
 	xff := r.Header.Get("X-Forwarded-For")
@@ -114,16 +128,30 @@ func log(
 		ResponseContentType:     responseContentType,
 		ResponseContentEncoding: responseContentEncoding,
 		ProtocolVersion:         r.ProtoMajor,
+		Error:                   errorMessage,
+		RequestID:               requestId,
 	}
 
 	_, _ = fmt.Fprint(cfg.Output, cfg.LogFormatter(params))
 }
 
 func defaultLogFormatter(params LogFormatterParams) string {
-	logFormat := "%v | HTTP/%d | %4d | %10v | %30s | %30s | %15s | %7s %-7s \n"
+	logFormat := "%v | HTTP/%d | %4d | %10v | %30s | %30s | %15s | %7s %-7s %s%s\n"
 
 	timeFormat := time.DateTime
 
+	requestIdSuffix := ""
+
+	if params.RequestID != "" {
+		requestIdSuffix = "| " + params.RequestID + " "
+	}
+
+	errorSuffix := ""
+
+	if params.Error != "" {
+		errorSuffix = "| " + params.Error
+	}
+
 	return fmt.Sprintf(
 		logFormat,
 		params.Timestamp.Format(timeFormat),
@@ -135,6 +163,8 @@ func defaultLogFormatter(params LogFormatterParams) string {
 		params.ClientIP,
 		params.Method,
 		params.Path,
+		requestIdSuffix,
+		errorSuffix,
 	)
 }
 
@@ -156,6 +186,8 @@ type LogFormatterParams struct {
 	ResponseContentType     string              `json:"response_content_type" yaml:"response_content_type"`
 	ResponseContentEncoding string              `json:"response_content_encoding" yaml:"response_content_encoding"`
 	ProtocolVersion         int                 `json:"protocol_version" yaml:"protocol_version"`
+	Error                   string              `json:"error,omitempty" yaml:"error,omitempty"`
+	RequestID               string              `json:"request_id,omitempty" yaml:"request_id,omitempty"`
 }
 
 func (l LogFormatterParams) String() string {
@@ -175,6 +207,8 @@ func (l LogFormatterParams) String() string {
 				"ResponseContentType: %s",
 				"ResponseContentEncoding: %s",
 				"ProtocolVersion: %d",
+				"Error: %s",
+				"RequestID: %s",
 			},
 			", ",
 		),
@@ -194,5 +228,7 @@ func (l LogFormatterParams) String() string {
 		l.ResponseContentType,
 		l.ResponseContentEncoding,
 		l.ProtocolVersion,
+		l.Error,
+		l.RequestID,
 	)
 }
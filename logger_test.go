@@ -134,6 +134,36 @@ func Test_defaultLogFormatter(t *testing.T) {
 				"text/plain",
 			},
 		},
+		{
+			name: "with error",
+			args: LogFormatterParams{
+				Timestamp:       time.Now(),
+				ProtocolVersion: 1,
+				StatusCode:      500,
+				Method:          http.MethodGet,
+				Path:            "/api/v1/books",
+				Error:           "connection refused",
+			},
+			want: []string{
+				"500",
+				"/api/v1/books | connection refused",
+			},
+		},
+		{
+			name: "with request id",
+			args: LogFormatterParams{
+				Timestamp:       time.Now(),
+				ProtocolVersion: 1,
+				StatusCode:      500,
+				Method:          http.MethodGet,
+				Path:            "/api/v1/books",
+				Error:           "connection refused",
+				RequestID:       "0190b3c2-7b1e-7c3a-9f00-5d2c6a1e8b11",
+			},
+			want: []string{
+				"/api/v1/books | 0190b3c2-7b1e-7c3a-9f00-5d2c6a1e8b11 | connection refused",
+			},
+		},
 	}
 
 	for _, tt := range tests {
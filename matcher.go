@@ -0,0 +1,148 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type Matcher func(r *http.Request) error
+
+func MatchHeader(key, value string) Matcher {
+	return func(r *http.Request) error {
+		for _, each := range r.Header.Values(key) {
+			if each == value {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: header %s != %q", ErrNoMatch, key, value)
+	}
+}
+
+func MatchHeaderRegexp(key string, re *regexp.Regexp) Matcher {
+	return func(r *http.Request) error {
+		for _, each := range r.Header.Values(key) {
+			if re.MatchString(each) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: header %s !~ %s", ErrNoMatch, key, re)
+	}
+}
+
+func MatchQuery(key string) Matcher {
+	return func(r *http.Request) error {
+		if r.URL.Query().Has(key) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: query %s absent", ErrNoMatch, key)
+	}
+}
+
+func MatchQueryValue(key, value string) Matcher {
+	return func(r *http.Request) error {
+		if slices.Contains(r.URL.Query()[key], value) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: query %s != %q", ErrNoMatch, key, value)
+	}
+}
+
+func MatchContentType(mediaTypes ...string) Matcher {
+	return func(r *http.Request) error {
+		contentType := r.Header.Get("Content-Type")
+
+		if contentType != "" {
+			for _, mediaType := range mediaTypes {
+				if negotiate.MediaMatches(mediaType, contentType) {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("%w: content-type %q", ErrUnsupportedMediaType, contentType)
+	}
+}
+
+func MatchAccept(mediaTypes ...string) Matcher {
+	return func(r *http.Request) error {
+		accept := strings.Join(r.Header.Values("Accept"), ",")
+
+		_, ok := negotiate.Media(accept, mediaTypes)
+
+		if ok {
+			return nil
+		}
+
+		return fmt.Errorf("%w: accept %q", ErrNotAcceptable, accept)
+	}
+}
+
+func MatchScheme(schemes ...string) Matcher {
+	return func(r *http.Request) error {
+		scheme := schemeOf(r)
+
+		for _, each := range schemes {
+			if strings.EqualFold(each, scheme) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: scheme %s", ErrNoMatch, scheme)
+	}
+}
+
+func MatchHost(host string) Matcher {
+	return func(r *http.Request) error {
+		requestHost := r.Host
+
+		if h, _, err := net.SplitHostPort(requestHost); err == nil {
+			requestHost = h
+		}
+
+		if strings.EqualFold(requestHost, host) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: host %s", ErrNoMatch, requestHost)
+	}
+}
+
+func schemeOf(r *http.Request) string {
+	if r.URL.Scheme != "" {
+		return strings.ToLower(r.URL.Scheme)
+	}
+
+	if r.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
+
+func matchStatus(err error) int {
+	if errors.Is(err, ErrUnsupportedMediaType) {
+		return http.StatusUnsupportedMediaType
+	}
+
+	if errors.Is(err, ErrNotAcceptable) {
+		return http.StatusNotAcceptable
+	}
+
+	return http.StatusNotFound
+}
+
+var (
+	ErrNoMatch              = errors.New("route did not match")
+	ErrNotAcceptable        = errors.New("not acceptable")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
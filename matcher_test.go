@@ -0,0 +1,184 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestRouter_ServeHTTP_Matchers(t *testing.T) {
+	t.Parallel()
+
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	router := NewRouter()
+
+	router.PostFunc(
+		"/api/v2/books",
+		respond("grpc-web"),
+		WithMatchers(MatchContentType("application/grpc-web", "application/grpc-web+proto")),
+	)
+
+	router.PostFunc(
+		"/api/v2/books",
+		respond("json"),
+		WithMatchers(MatchContentType("application/json")),
+	)
+
+	router.GetFunc("/api/v2/books", respond("default"))
+
+	router.GetFunc(
+		"/api/v2/books",
+		respond("csv"),
+		WithMatchers(MatchQueryValue("format", "csv")),
+	)
+
+	router.GetFunc(
+		"/api/v2/users",
+		respond("xml"),
+		WithMatchers(MatchAccept("application/xml")),
+	)
+
+	router.GetFunc(
+		"/api/v2/users",
+		respond("json"),
+		WithMatchers(MatchAccept("application/json")),
+	)
+
+	router.GetFunc(
+		"/api/v2/admin",
+		respond("admin"),
+		WithMatchers(
+			MatchHeaderRegexp("Authorization", regexp.MustCompile("^Bearer .+$")),
+			MatchScheme("https"),
+		),
+	)
+
+	router.GetFunc(
+		"/api/v2/debug",
+		respond("debug"),
+		WithMatchers(MatchHeader("X-Debug", "1"), MatchQuery("trace")),
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     map[string]string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should route by content type to grpc-web",
+			method:     http.MethodPost,
+			target:     "/api/v2/books",
+			header:     map[string]string{"Content-Type": "application/grpc-web+proto"},
+			wantStatus: http.StatusOK,
+			wantBody:   "grpc-web",
+		},
+		{
+			name:       "should route by content type to json",
+			method:     http.MethodPost,
+			target:     "/api/v2/books",
+			header:     map[string]string{"Content-Type": "application/json; charset=utf-8"},
+			wantStatus: http.StatusOK,
+			wantBody:   "json",
+		},
+		{
+			name:       "should respond 415 when no content type fits",
+			method:     http.MethodPost,
+			target:     "/api/v2/books",
+			header:     map[string]string{"Content-Type": "text/plain"},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "should prefer the more specific route",
+			method:     http.MethodGet,
+			target:     "/api/v2/books?format=csv",
+			wantStatus: http.StatusOK,
+			wantBody:   "csv",
+		},
+		{
+			name:       "should fall back to route without matchers",
+			method:     http.MethodGet,
+			target:     "/api/v2/books?format=json",
+			wantStatus: http.StatusOK,
+			wantBody:   "default",
+		},
+		{
+			name:       "should route by accept",
+			method:     http.MethodGet,
+			target:     "/api/v2/users",
+			header:     map[string]string{"Accept": "application/xml"},
+			wantStatus: http.StatusOK,
+			wantBody:   "xml",
+		},
+		{
+			name:       "should respond 406 when nothing is acceptable",
+			method:     http.MethodGet,
+			target:     "/api/v2/users",
+			header:     map[string]string{"Accept": "text/csv"},
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "should respond 404 when header does not match",
+			method:     http.MethodGet,
+			target:     "https://example.com/api/v2/admin",
+			header:     map[string]string{"Authorization": "Basic Zm9vOmJhcg=="},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should match header regexp and scheme",
+			method:     http.MethodGet,
+			target:     "https://example.com/api/v2/admin",
+			header:     map[string]string{"Authorization": "Bearer token"},
+			wantStatus: http.StatusOK,
+			wantBody:   "admin",
+		},
+		{
+			name:       "should respond 404 on scheme mismatch",
+			method:     http.MethodGet,
+			target:     "http://example.com/api/v2/admin",
+			header:     map[string]string{"Authorization": "Bearer token"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should match header and query presence",
+			method:     http.MethodGet,
+			target:     "/api/v2/debug?trace",
+			header:     map[string]string{"X-Debug": "1"},
+			wantStatus: http.StatusOK,
+			wantBody:   "debug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "code should be %d", tt.wantStatus)
+
+			if tt.wantBody == "" {
+				return
+			}
+
+			assert.Equalf(t, tt.wantBody, w.Body.String(), "body should be %s", tt.wantBody)
+		})
+	}
+}
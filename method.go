@@ -2,8 +2,35 @@ package http
 
 import (
 	p "path"
+	"strings"
 )
 
 func pathWithMethod(method, path string) string {
+	if method == "" {
+		method = "{" + methodParam + "}"
+	}
+
 	return p.Join(method, path)
 }
+
+func parsePattern(pattern string) (method, host, path string) {
+	pattern = strings.TrimSpace(pattern)
+
+	if before, after, ok := strings.Cut(pattern, " "); ok && !strings.HasPrefix(before, "/") {
+		method, pattern = before, strings.TrimLeft(after, " \t")
+	}
+
+	if strings.HasPrefix(pattern, "/") {
+		return method, "", pattern
+	}
+
+	slash := strings.Index(pattern, "/")
+
+	if slash < 0 {
+		return method, pattern, "/"
+	}
+
+	return method, pattern[:slash], pattern[slash:]
+}
+
+const methodParam = "http_method"
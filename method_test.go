@@ -63,3 +63,33 @@ func Test_pathWithMethod(t *testing.T) {
 		})
 	}
 }
+
+func Test_parsePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern    string
+		wantMethod string
+		wantHost   string
+		wantPath   string
+	}{
+		{pattern: "/books", wantPath: "/books"},
+		{pattern: "GET /books/{id}", wantMethod: "GET", wantPath: "/books/{id}"},
+		{pattern: "POST  /books", wantMethod: "POST", wantPath: "/books"},
+		{pattern: "example.com/books", wantHost: "example.com", wantPath: "/books"},
+		{pattern: "GET example.com/static/{path...}", wantMethod: "GET", wantHost: "example.com", wantPath: "/static/{path...}"},
+		{pattern: "GET example.com", wantMethod: "GET", wantHost: "example.com", wantPath: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			method, host, path := parsePattern(tt.pattern)
+
+			assert.Equalf(t, tt.wantMethod, method, "parsePattern() method = %v, want %v", method, tt.wantMethod)
+
+			assert.Equalf(t, tt.wantHost, host, "parsePattern() host = %v, want %v", host, tt.wantHost)
+
+			assert.Equalf(t, tt.wantPath, path, "parsePattern() path = %v, want %v", path, tt.wantPath)
+		})
+	}
+}
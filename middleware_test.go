@@ -63,3 +63,57 @@ func TestMiddlewares_Chain(t *testing.T) {
 
 	final.AssertNumberOfCalls(t, "ServeHTTP", 1)
 }
+
+func TestRouteFromRequest_Middleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   string
+	}{
+		{
+			name:   "should resolve the route before the handler runs",
+			method: http.MethodGet,
+			target: "/books/7",
+			want:   "GET /books/{id}",
+		},
+		{
+			name:   "should fall back to get for head requests",
+			method: http.MethodHead,
+			target: "/books/7",
+			want:   "GET /books/{id}",
+		},
+		{
+			name:   "should report no route when nothing matches",
+			method: http.MethodGet,
+			target: "/authors/7",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+
+			router := NewRouter()
+
+			router.Use(func(w http.ResponseWriter, r *http.Request, next Next) {
+				route, ok := RouteFromRequest(r)
+
+				if ok {
+					got = route.String()
+				}
+
+				next(r)
+			})
+
+			router.GetFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.Equalf(t, tt.want, got, "want %v, got %v", tt.want, got)
+		})
+	}
+}
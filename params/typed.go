@@ -0,0 +1,212 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/internal/convert"
+	"github.com/aakash-rajur/http/validate"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+func Get[T any](r *http.Request, key string) (T, error) {
+	var value T
+
+	raw, err := lookup(r, key)
+
+	if err != nil {
+		return value, err
+	}
+
+	value, err = convert.Parse[T](raw)
+
+	if err != nil {
+		return value, &Error{Key: key, Value: raw, Err: err}
+	}
+
+	return value, nil
+}
+
+func String(r *http.Request, key string) (string, error) {
+	return lookup(r, key)
+}
+
+func Int(r *http.Request, key string) (int, error) {
+	return Get[int](r, key)
+}
+
+func Int64(r *http.Request, key string) (int64, error) {
+	return Get[int64](r, key)
+}
+
+func Uint(r *http.Request, key string) (uint, error) {
+	return Get[uint](r, key)
+}
+
+func Float64(r *http.Request, key string) (float64, error) {
+	return Get[float64](r, key)
+}
+
+func Bool(r *http.Request, key string) (bool, error) {
+	return Get[bool](r, key)
+}
+
+func UUID(r *http.Request, key string) (string, error) {
+	raw, err := lookup(r, key)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !uuidPattern.MatchString(raw) {
+		return "", &Error{Key: key, Value: raw, Err: ErrInvalidUUID}
+	}
+
+	return strings.ToLower(raw), nil
+}
+
+func Time(r *http.Request, key, layout string) (time.Time, error) {
+	raw, err := lookup(r, key)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(layout, raw)
+
+	if err != nil {
+		return time.Time{}, &Error{Key: key, Value: raw, Err: err}
+	}
+
+	return t, nil
+}
+
+func Bind(r *http.Request, dst any) error {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("params: bind target must be a non-nil pointer to struct, got %T", dst)
+	}
+
+	p, _ := FromRequest(r)
+
+	err := bind(p, v.Elem())
+
+	if err != nil {
+		return err
+	}
+
+	return validate.Struct(dst)
+}
+
+func bind(p Params, v reflect.Value) error {
+	errs := make([]error, 0)
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i += 1 {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			err := bind(p, v.Field(i))
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		key, ok := field.Tag.Lookup(tagName)
+
+		if !ok || key == "-" {
+			continue
+		}
+
+		raw, ok := p[key]
+
+		if !ok {
+			errs = append(errs, &Error{Key: key, Err: ErrMissing})
+
+			continue
+		}
+
+		err := setField(v.Field(i), field.Tag, raw)
+
+		if err != nil {
+			errs = append(errs, &Error{Key: key, Value: raw, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setField(v reflect.Value, tag reflect.StructTag, raw string) error {
+	if v.Type() != timeType {
+		return convert.Set(v, raw)
+	}
+
+	layout := tag.Get("layout")
+
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	t, err := time.Parse(layout, raw)
+
+	if err != nil {
+		return err
+	}
+
+	v.Set(reflect.ValueOf(t))
+
+	return nil
+}
+
+func lookup(r *http.Request, key string) (string, error) {
+	p, _ := FromRequest(r)
+
+	raw, ok := p[key]
+
+	if !ok {
+		return "", &Error{Key: key, Err: ErrMissing}
+	}
+
+	return raw, nil
+}
+
+type Error struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("param %s: %s", e.Key, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Status() int {
+	return http.StatusBadRequest
+}
+
+var (
+	ErrMissing     = errors.New("missing")
+	ErrInvalidUUID = errors.New("invalid uuid")
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+const tagName = "path"
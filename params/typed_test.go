@@ -0,0 +1,177 @@
+package params
+
+import (
+	"errors"
+	"github.com/aakash-rajur/http/validate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTypedAccessors(t *testing.T) {
+	t.Parallel()
+
+	r := requestWithParams(
+		Params{
+			"id":     "42",
+			"name":   "alchemist",
+			"uuid":   "0F8FAD5B-D9CB-469F-A165-70867728950E",
+			"date":   "2024-01-07",
+			"ratio":  "0.5",
+			"active": "true",
+			"bad":    "forty-two",
+		},
+	)
+
+	id, err := Int(r, "id")
+
+	assert.NoErrorf(t, err, "Int() err = %v", err)
+
+	assert.Equalf(t, 42, id, "Int() = %v, want 42", id)
+
+	id64, err := Int64(r, "id")
+
+	assert.NoErrorf(t, err, "Int64() err = %v", err)
+
+	assert.Equalf(t, int64(42), id64, "Int64() = %v, want 42", id64)
+
+	name, err := String(r, "name")
+
+	assert.NoErrorf(t, err, "String() err = %v", err)
+
+	assert.Equalf(t, "alchemist", name, "String() = %v, want alchemist", name)
+
+	ratio, err := Float64(r, "ratio")
+
+	assert.NoErrorf(t, err, "Float64() err = %v", err)
+
+	assert.Equalf(t, 0.5, ratio, "Float64() = %v, want 0.5", ratio)
+
+	active, err := Bool(r, "active")
+
+	assert.NoErrorf(t, err, "Bool() err = %v", err)
+
+	assert.Truef(t, active, "Bool() = %v, want true", active)
+
+	uuid, err := UUID(r, "uuid")
+
+	assert.NoErrorf(t, err, "UUID() err = %v", err)
+
+	assert.Equalf(t, "0f8fad5b-d9cb-469f-a165-70867728950e", uuid, "UUID() = %v", uuid)
+
+	date, err := Time(r, "date", time.DateOnly)
+
+	assert.NoErrorf(t, err, "Time() err = %v", err)
+
+	assert.Equalf(t, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), date, "Time() = %v", date)
+
+	_, err = Int(r, "bad")
+
+	assert.EqualErrorf(t, err, `param bad: cannot convert "forty-two" to int: invalid syntax`, "Int() err = %v", err)
+
+	assert.ErrorIsf(t, err, strconv.ErrSyntax, "Int() err = %v", err)
+
+	_, err = Get[uint8](r, "missing")
+
+	assert.ErrorIsf(t, err, ErrMissing, "Get() err = %v", err)
+
+	_, err = UUID(r, "name")
+
+	assert.ErrorIsf(t, err, ErrInvalidUUID, "UUID() err = %v", err)
+
+	var paramErr *Error
+
+	assert.Truef(t, errors.As(err, &paramErr), "UUID() err should be *Error")
+
+	assert.Equalf(t, "name", paramErr.Key, "Error.Key = %v, want name", paramErr.Key)
+
+	assert.Equalf(t, http.StatusBadRequest, paramErr.Status(), "Error.Status() = %v, want 400", paramErr.Status())
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	type Base struct {
+		Owner string `path:"owner"`
+	}
+
+	type target struct {
+		Base
+		Id      int       `path:"id"`
+		Slug    *string   `path:"slug"`
+		Since   time.Time `path:"since" layout:"2006-01-02"`
+		Ignored string
+	}
+
+	t.Run("should bind path params", func(t *testing.T) {
+		r := requestWithParams(Params{"owner": "aakash", "id": "10", "slug": "go", "since": "2024-01-07"})
+
+		got := target{}
+
+		err := Bind(r, &got)
+
+		assert.NoErrorf(t, err, "Bind() err = %v", err)
+
+		slug := "go"
+
+		want := target{
+			Base:  Base{Owner: "aakash"},
+			Id:    10,
+			Slug:  &slug,
+			Since: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		}
+
+		assert.Equalf(t, want, got, "Bind() = %v, want %v", got, want)
+	})
+
+	t.Run("should aggregate errors", func(t *testing.T) {
+		r := requestWithParams(Params{"owner": "aakash", "id": "ten", "slug": "go"})
+
+		err := Bind(r, &target{})
+
+		assert.ErrorIsf(t, err, ErrMissing, "Bind() err = %v", err)
+
+		assert.ErrorIsf(t, err, strconv.ErrSyntax, "Bind() err = %v", err)
+
+		assert.EqualErrorf(
+			t,
+			err,
+			"param id: cannot convert \"ten\" to int: invalid syntax\nparam since: missing",
+			"Bind() err = %v",
+			err,
+		)
+	})
+
+	t.Run("should validate converted params", func(t *testing.T) {
+		type page struct {
+			Id   int `path:"id" validate:"min=1"`
+			Page int `path:"page" validate:"min=1,max=100"`
+		}
+
+		r := requestWithParams(Params{"id": "0", "page": "500"})
+
+		err := Bind(r, &page{})
+
+		want := validate.Errors{
+			{Field: "id", Rule: "min", Param: "1", Message: "must be at least 1"},
+			{Field: "page", Rule: "max", Param: "100", Message: "must be at most 100"},
+		}
+
+		assert.Equalf(t, want, err, "Bind() err = %v, want %v", err, want)
+	})
+
+	t.Run("should reject non struct pointer", func(t *testing.T) {
+		err := Bind(requestWithParams(Params{}), target{})
+
+		assert.Errorf(t, err, "Bind() should fail for non pointer")
+	})
+}
+
+func requestWithParams(p Params) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	return r.WithContext(p.WithinContext(r.Context()))
+}
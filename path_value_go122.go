@@ -0,0 +1,14 @@
+//go:build go1.22 && !go1.23
+
+package http
+
+import (
+	"github.com/aakash-rajur/http/params"
+	"net/http"
+)
+
+func withPattern(r *http.Request, _ string, p params.Params) {
+	for key, value := range p {
+		r.SetPathValue(key, value)
+	}
+}
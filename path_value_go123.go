@@ -0,0 +1,16 @@
+//go:build go1.23
+
+package http
+
+import (
+	"github.com/aakash-rajur/http/params"
+	"net/http"
+)
+
+func withPattern(r *http.Request, pattern string, p params.Params) {
+	r.Pattern = pattern
+
+	for key, value := range p {
+		r.SetPathValue(key, value)
+	}
+}
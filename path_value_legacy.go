@@ -0,0 +1,10 @@
+//go:build !go1.22
+
+package http
+
+import (
+	"github.com/aakash-rajur/http/params"
+	"net/http"
+)
+
+func withPattern(_ *http.Request, _ string, _ params.Params) {}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"errors"
+	"github.com/aakash-rajur/http/problem"
+	"net/http"
+)
+
+type ErrorRenderer func(w http.ResponseWriter, r *http.Request, details *problem.Details)
+
+func RenderError(w http.ResponseWriter, r *http.Request, err error) {
+	renderer := problem.Render
+
+	router, ok := routerFromRequest(r)
+
+	if ok {
+		state := router.state.Load()
+
+		if state.errors != nil {
+			renderer = state.errors
+		}
+	}
+
+	var known *problem.Details
+
+	if !errors.As(err, &known) {
+		recordError(w, err)
+	}
+
+	details := *problem.FromError(err)
+
+	if details.Instance == "" {
+		details.Instance = r.URL.Path
+	}
+
+	renderer(w, r, &details)
+}
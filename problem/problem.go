@@ -0,0 +1,211 @@
+package problem
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/validate"
+	"net/http"
+	"sort"
+)
+
+const (
+	MediaTypeJSON = "application/problem+json"
+	MediaTypeXML  = "application/problem+xml"
+	Namespace     = "urn:ietf:rfc:7807"
+)
+
+type Details struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+func New(status int, detail string) *Details {
+	return &Details{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (d *Details) With(key string, value any) *Details {
+	if d.Extensions == nil {
+		d.Extensions = make(map[string]any)
+	}
+
+	d.Extensions[key] = value
+
+	return d
+}
+
+func (d *Details) Error() string {
+	if d.Detail == "" {
+		return fmt.Sprintf("%d %s", d.Status, d.Title)
+	}
+
+	return fmt.Sprintf("%d %s: %s", d.Status, d.Title, d.Detail)
+}
+
+func (d *Details) members() map[string]any {
+	members := make(map[string]any, len(d.Extensions)+5)
+
+	for key, value := range d.Extensions {
+		if isMember(key) {
+			continue
+		}
+
+		members[key] = value
+	}
+
+	if d.Type != "" {
+		members["type"] = d.Type
+	}
+
+	if d.Title != "" {
+		members["title"] = d.Title
+	}
+
+	if d.Status != 0 {
+		members["status"] = d.Status
+	}
+
+	if d.Detail != "" {
+		members["detail"] = d.Detail
+	}
+
+	if d.Instance != "" {
+		members["instance"] = d.Instance
+	}
+
+	return members
+}
+
+func (d *Details) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.members())
+}
+
+func (d *Details) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &raw)
+
+	if err != nil {
+		return err
+	}
+
+	targets := map[string]any{
+		"type":     &d.Type,
+		"title":    &d.Title,
+		"status":   &d.Status,
+		"detail":   &d.Detail,
+		"instance": &d.Instance,
+	}
+
+	for key, value := range raw {
+		target, ok := targets[key]
+
+		if !ok {
+			var extension any
+
+			err = json.Unmarshal(value, &extension)
+
+			if err != nil {
+				return err
+			}
+
+			d.With(key, extension)
+
+			continue
+		}
+
+		err = json.Unmarshal(value, target)
+
+		if err != nil {
+			return fmt.Errorf("problem: member %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Details) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{
+		Name: xml.Name{Space: Namespace, Local: "problem"},
+	}
+
+	err := e.EncodeToken(start)
+
+	if err != nil {
+		return err
+	}
+
+	members := d.members()
+
+	keys := make([]string, 0, len(members))
+
+	for key := range members {
+		keys = append(keys, key)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return memberOrder(keys[i]) < memberOrder(keys[j]) ||
+			memberOrder(keys[i]) == memberOrder(keys[j]) && keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		err = e.EncodeElement(members[key], xml.StartElement{Name: xml.Name{Local: key}})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func FromError(err error) *Details {
+	var details *Details
+
+	if errors.As(err, &details) {
+		return details
+	}
+
+	var fieldErrors validate.Errors
+
+	if errors.As(err, &fieldErrors) {
+		return New(fieldErrors.Status(), "request validation failed").With("errors", []validate.FieldError(fieldErrors))
+	}
+
+	var coded interface{ Status() int }
+
+	if errors.As(err, &coded) {
+		if coded.Status() >= http.StatusInternalServerError {
+			return New(coded.Status(), "")
+		}
+
+		return New(coded.Status(), err.Error())
+	}
+
+	return New(http.StatusInternalServerError, "")
+}
+
+var members = []string{"type", "title", "status", "detail", "instance"}
+
+func isMember(key string) bool {
+	return memberOrder(key) < len(members)
+}
+
+func memberOrder(key string) int {
+	for index, member := range members {
+		if member == key {
+			return index
+		}
+	}
+
+	return len(members)
+}
@@ -0,0 +1,165 @@
+package problem
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/validate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestDetails_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		details  *Details
+		expected string
+	}{
+		{
+			name:     "should omit empty members",
+			details:  New(http.StatusNotFound, ""),
+			expected: `{"status":404,"title":"Not Found"}`,
+		},
+		{
+			name: "should flatten extensions",
+			details: (&Details{
+				Type:     "https://example.com/probs/out-of-credit",
+				Title:    "You do not have enough credit.",
+				Status:   http.StatusForbidden,
+				Detail:   "Your current balance is 30, but that costs 50.",
+				Instance: "/account/12345/msgs/abc",
+			}).With("balance", 30).With("accounts", []string{"/account/12345", "/account/67890"}),
+			expected: `{"accounts":["/account/12345","/account/67890"],"balance":30,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","status":403,"title":"You do not have enough credit.","type":"https://example.com/probs/out-of-credit"}`,
+		},
+		{
+			name:     "should not let extensions override members",
+			details:  New(http.StatusBadRequest, "bad").With("status", 200),
+			expected: `{"detail":"bad","status":400,"title":"Bad Request"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := json.Marshal(tc.details)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.JSONEqf(t, tc.expected, string(actual), "want %v, got %v", tc.expected, string(actual))
+		})
+	}
+}
+
+func TestDetails_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	input := `{"type":"about:blank","title":"Forbidden","status":403,"detail":"no","instance":"/a","balance":30}`
+
+	var actual Details
+
+	err := json.Unmarshal([]byte(input), &actual)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	expected := Details{
+		Type:       "about:blank",
+		Title:      "Forbidden",
+		Status:     http.StatusForbidden,
+		Detail:     "no",
+		Instance:   "/a",
+		Extensions: map[string]any{"balance": float64(30)},
+	}
+
+	assert.Equalf(t, expected, actual, "want %v, got %v", expected, actual)
+
+	err = json.Unmarshal([]byte(`{"status":"403"}`), &actual)
+
+	assert.Errorf(t, err, "want error, got nil")
+}
+
+func TestDetails_MarshalXML(t *testing.T) {
+	t.Parallel()
+
+	details := New(http.StatusForbidden, "no credit").With("balance", 30)
+
+	details.Instance = "/account"
+
+	actual, err := xml.Marshal(details)
+
+	assert.NoErrorf(t, err, "want no error, got %v", err)
+
+	expected := `<problem xmlns="urn:ietf:rfc:7807"><title>Forbidden</title><status>403</status><detail>no credit</detail><instance>/account</instance><balance>30</balance></problem>`
+
+	assert.Equalf(t, expected, string(actual), "want %v, got %v", expected, string(actual))
+}
+
+type teapot struct{}
+
+func (teapot) Error() string {
+	return "short and stout"
+}
+
+func (teapot) Status() int {
+	return http.StatusTeapot
+}
+
+type unavailable struct{}
+
+func (unavailable) Error() string {
+	return "database password is hunter2"
+}
+
+func (unavailable) Status() int {
+	return http.StatusServiceUnavailable
+}
+
+func TestFromError(t *testing.T) {
+	t.Parallel()
+
+	conflict := New(http.StatusConflict, "exists")
+
+	fieldErrors := validate.Errors{{Field: "name", Rule: "required", Message: "is required"}}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected *Details
+	}{
+		{
+			name:     "should pass through details",
+			err:      fmt.Errorf("wrapped: %w", conflict),
+			expected: conflict,
+		},
+		{
+			name:     "should describe validation errors",
+			err:      fieldErrors,
+			expected: New(http.StatusBadRequest, "request validation failed").With("errors", []validate.FieldError(fieldErrors)),
+		},
+		{
+			name:     "should use client status and message",
+			err:      teapot{},
+			expected: New(http.StatusTeapot, "short and stout"),
+		},
+		{
+			name:     "should hide server error messages",
+			err:      unavailable{},
+			expected: New(http.StatusServiceUnavailable, ""),
+		},
+		{
+			name:     "should hide unknown errors",
+			err:      errors.New("pq: relation users does not exist"),
+			expected: New(http.StatusInternalServerError, ""),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := FromError(tc.err)
+
+			assert.Equalf(t, tc.expected, actual, "want %v, got %v", tc.expected, actual)
+		})
+	}
+}
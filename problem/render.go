@@ -0,0 +1,57 @@
+package problem
+
+import (
+	"bytes"
+	"github.com/aakash-rajur/http/codec"
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"net/http"
+	"strconv"
+)
+
+var mediaTypes = []string{MediaTypeJSON, MediaTypeXML, "application/json", "application/xml"}
+
+func Render(w http.ResponseWriter, r *http.Request, details *Details) {
+	negotiate.AddVary(w.Header(), "Accept")
+
+	offer, _ := negotiate.Media(r.Header.Get("Accept"), mediaTypes)
+
+	var c codec.Codec = codec.JSON{}
+
+	mediaType := MediaTypeJSON
+
+	if offer == MediaTypeXML || offer == "application/xml" {
+		c, mediaType = codec.XML{}, MediaTypeXML
+	}
+
+	status := details.Status
+
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	var buf bytes.Buffer
+
+	err := c.Encode(&buf, details)
+
+	if err != nil {
+		http.Error(w, http.StatusText(status), status)
+
+		return
+	}
+
+	header := w.Header()
+
+	header.Set("Content-Type", mediaType)
+
+	header.Set("Content-Length", strconv.Itoa(buf.Len()))
+
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	w.WriteHeader(status)
+
+	_, _ = w.Write(buf.Bytes())
+}
+
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	Render(w, r, FromError(err))
+}
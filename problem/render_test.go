@@ -0,0 +1,80 @@
+package problem
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		accept      string
+		details     *Details
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "should default to json",
+			accept:      "",
+			details:     New(http.StatusNotFound, ""),
+			status:      http.StatusNotFound,
+			contentType: MediaTypeJSON,
+			body:        "{\"status\":404,\"title\":\"Not Found\"}\n",
+		},
+		{
+			name:        "should render xml when preferred",
+			accept:      "application/xml;q=0.9, application/json;q=0.1",
+			details:     New(http.StatusNotFound, ""),
+			status:      http.StatusNotFound,
+			contentType: MediaTypeXML,
+			body:        `<problem xmlns="urn:ietf:rfc:7807"><title>Not Found</title><status>404</status></problem>`,
+		},
+		{
+			name:        "should fall back to json when nothing is acceptable",
+			accept:      "text/html",
+			details:     New(http.StatusMethodNotAllowed, ""),
+			status:      http.StatusMethodNotAllowed,
+			contentType: MediaTypeJSON,
+			body:        "{\"status\":405,\"title\":\"Method Not Allowed\"}\n",
+		},
+		{
+			name:        "should fall back to plain text when encoding fails",
+			accept:      MediaTypeXML,
+			details:     New(http.StatusBadRequest, "").With("fields", map[string]string{"a": "b"}),
+			status:      http.StatusBadRequest,
+			contentType: "text/plain; charset=utf-8",
+			body:        "Bad Request\n",
+		},
+		{
+			name:        "should default a missing status to 500",
+			accept:      MediaTypeJSON,
+			details:     &Details{Title: "oops"},
+			status:      http.StatusInternalServerError,
+			contentType: MediaTypeJSON,
+			body:        "{\"title\":\"oops\"}\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			r.Header.Set("Accept", tc.accept)
+
+			Render(w, r, tc.details)
+
+			assert.Equalf(t, tc.status, w.Code, "want %v, got %v", tc.status, w.Code)
+
+			assert.Equalf(t, tc.contentType, w.Header().Get("Content-Type"), "want %v, got %v", tc.contentType, w.Header().Get("Content-Type"))
+
+			assert.Equalf(t, tc.body, w.Body.String(), "want %v, got %v", tc.body, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package http
+
+import (
+	"errors"
+	"github.com/aakash-rajur/http/problem"
+	v "github.com/aakash-rajur/http/validate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_Problems(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.GetFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.DeleteFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.PostFunc(
+		"/books",
+		func(w http.ResponseWriter, r *http.Request) {
+			RenderError(w, r, v.Errors{{Field: "name", Rule: "required", Message: "is required"}})
+		},
+		WithMatchers(MatchContentType("application/json")),
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     map[string]string
+		wantStatus int
+		wantType   string
+		wantAllow  string
+		wantBody   string
+	}{
+		{
+			name:       "should render 404 as problem json",
+			method:     http.MethodGet,
+			target:     "/authors",
+			wantStatus: http.StatusNotFound,
+			wantType:   problem.MediaTypeJSON,
+			wantBody:   `{"instance":"/authors","status":404,"title":"Not Found"}`,
+		},
+		{
+			name:       "should render 405 with allowed methods",
+			method:     http.MethodPut,
+			target:     "/books/1",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantType:   problem.MediaTypeJSON,
+			wantAllow:  "DELETE, GET, HEAD",
+			wantBody:   `{"instance":"/books/1","status":405,"title":"Method Not Allowed"}`,
+		},
+		{
+			name:       "should render 415 from matchers",
+			method:     http.MethodPost,
+			target:     "/books",
+			header:     map[string]string{"Content-Type": "text/csv"},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantType:   problem.MediaTypeJSON,
+			wantBody:   `{"instance":"/books","status":415,"title":"Unsupported Media Type"}`,
+		},
+		{
+			name:       "should render validation errors from handlers",
+			method:     http.MethodPost,
+			target:     "/books",
+			header:     map[string]string{"Content-Type": "application/json"},
+			wantStatus: http.StatusBadRequest,
+			wantType:   problem.MediaTypeJSON,
+			wantBody:   `{"detail":"request validation failed","errors":[{"field":"name","rule":"required","message":"is required"}],"instance":"/books","status":400,"title":"Bad Request"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			assert.Equalf(t, tt.wantType, w.Header().Get("Content-Type"), "want %v, got %v", tt.wantType, w.Header().Get("Content-Type"))
+
+			assert.Equalf(t, tt.wantAllow, w.Header().Get("Allow"), "want %v, got %v", tt.wantAllow, w.Header().Get("Allow"))
+
+			assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestRouter_ErrorRenderer(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.ErrorRenderer(func(w http.ResponseWriter, r *http.Request, details *problem.Details) {
+		w.WriteHeader(details.Status)
+
+		_, _ = w.Write([]byte(details.Title))
+	})
+
+	router.GetFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		RenderError(w, r, problem.New(http.StatusConflict, "taken"))
+	})
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should render router errors",
+			target:     "/missing",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "Not Found",
+		},
+		{
+			name:       "should render handler errors",
+			target:     "/fail",
+			wantStatus: http.StatusConflict,
+			wantBody:   "Conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	t.Parallel()
+
+	errDatabase := errors.New("connection refused")
+
+	router := NewRouter()
+
+	recorded := make(chan error, 1)
+
+	router.Use(func(w http.ResponseWriter, r *http.Request, next Next) {
+		if r.URL.Path == "/blocked" {
+			RenderError(w, r, errDatabase)
+		} else {
+			next(r)
+		}
+
+		rw, _ := UnwrapResponseWriter(w)
+
+		recorded <- rw.Err
+	})
+
+	router.GetFunc("/conflict", func(w http.ResponseWriter, r *http.Request) {
+		RenderError(w, r, problem.New(http.StatusConflict, "taken"))
+	})
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantErr    error
+	}{
+		{
+			name:       "should record errors hidden behind a generic problem",
+			target:     "/blocked",
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    errDatabase,
+		},
+		{
+			name:       "should not record problem details",
+			target:     "/conflict",
+			wantStatus: http.StatusConflict,
+			wantErr:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			got := <-recorded
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if tt.wantErr == nil {
+				assert.NoErrorf(t, got, "want no error, got %v", got)
+
+				return
+			}
+
+			assert.ErrorIsf(t, got, tt.wantErr, "want %v, got %v", tt.wantErr, got)
+		})
+	}
+
+	t.Run("should read the renderer while it is replaced", func(t *testing.T) {
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			router.ErrorRenderer(problem.Render)
+		}()
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blocked", nil))
+
+		<-recorded
+
+		<-done
+
+		assert.Equalf(t, http.StatusInternalServerError, w.Code, "want %v, got %v", http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestRouter_AllowedMethods(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.GetFunc("/books", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.PostFunc("/books", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.PutFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "collection", target: "/books", want: []string{"GET", "HEAD", "POST"}},
+		{name: "member", target: "/books/1", want: []string{"PUT"}},
+		{name: "unknown", target: "/authors", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := router.AllowedMethods(httptest.NewRequest(http.MethodOptions, tt.target, nil))
+
+			assert.Equalf(t, tt.want, actual, "want %v, got %v", tt.want, actual)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	h "github.com/aakash-rajur/http"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type KeyFunc func(r *http.Request) string
+
+func ByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(r.RemoteAddr))
+
+	if err != nil {
+		host = strings.TrimSpace(r.RemoteAddr)
+	}
+
+	if host == "" {
+		return ""
+	}
+
+	return "ip:" + host
+}
+
+func ByHeader(name string) KeyFunc {
+	return func(r *http.Request) string {
+		value := strings.TrimSpace(r.Header.Get(name))
+
+		if value == "" {
+			return ""
+		}
+
+		sum := sha256.Sum256([]byte(value))
+
+		return "key:" + hex.EncodeToString(sum[:16])
+	}
+}
+
+func ByUser(user func(r *http.Request) string) KeyFunc {
+	return func(r *http.Request) string {
+		id := user(r)
+
+		if id == "" {
+			return ""
+		}
+
+		return "user:" + id
+	}
+}
+
+func ByRoute(r *http.Request) string {
+	route, ok := h.RouteFromRequest(r)
+
+	if !ok {
+		return ""
+	}
+
+	return "route:" + route.String()
+}
+
+func First(keys ...KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		for _, key := range keys {
+			value := key(r)
+
+			if value != "" {
+				return value
+			}
+		}
+
+		return ""
+	}
+}
+
+func Join(keys ...KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		values := make([]string, 0, len(keys))
+
+		for _, key := range keys {
+			value := key(r)
+
+			if value == "" {
+				return ""
+			}
+
+			values = append(values, value)
+		}
+
+		return strings.Join(values, "|")
+	}
+}
@@ -0,0 +1,223 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Window     time.Duration
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+func NewTokenBucket(config TokenBucketConfig) *TokenBucket {
+	if config.Rate <= 0 {
+		panic(fmt.Sprintf("ratelimit: invalid token bucket rate %d", config.Rate))
+	}
+
+	if config.Store != nil && config.Name == "" {
+		panic("ratelimit: token bucket with a Store needs a Name")
+	}
+
+	cfg := TokenBucketConfig{
+		Name:  config.Name,
+		Rate:  config.Rate,
+		Per:   time.Second,
+		Burst: config.Rate,
+		Store: config.Store,
+		Now:   config.Now,
+	}
+
+	if config.Per > 0 {
+		cfg.Per = config.Per
+	}
+
+	if config.Burst > 0 {
+		cfg.Burst = config.Burst
+	}
+
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	if cfg.Store == nil {
+		cfg.Name = "token-bucket"
+
+		cfg.Store = NewMemoryStore(MemoryStoreConfig{Now: cfg.Now})
+	}
+
+	return &TokenBucket{config: cfg}
+}
+
+type TokenBucketConfig struct {
+	Name  string
+	Rate  int
+	Per   time.Duration
+	Burst int
+	Store Store
+	Now   func() time.Time
+}
+
+type TokenBucket struct {
+	config TokenBucketConfig
+}
+
+func (tb *TokenBucket) Allow(ctx context.Context, key string) (Result, error) {
+	cfg := tb.config
+
+	now := cfg.Now()
+
+	perSecond := float64(cfg.Rate) / cfg.Per.Seconds()
+
+	burst := float64(cfg.Burst)
+
+	window := seconds(burst / perSecond)
+
+	result := Result{Limit: cfg.Burst, Window: window}
+
+	err := cfg.Store.Update(ctx, cfg.Name+":"+key, window, func(state *State) {
+		tokens := burst
+
+		if !state.Time.IsZero() {
+			tokens = math.Min(burst, state.Value+now.Sub(state.Time).Seconds()*perSecond)
+		}
+
+		if tokens >= 1 {
+			result.Allowed = true
+
+			tokens -= 1
+		} else {
+			result.RetryAfter = seconds((1 - tokens) / perSecond)
+		}
+
+		state.Value, state.Time = tokens, now
+
+		result.Remaining = int(math.Floor(tokens))
+
+		result.Reset = seconds((burst - tokens) / perSecond)
+	})
+
+	return result, err
+}
+
+func NewSlidingWindow(config SlidingWindowConfig) *SlidingWindow {
+	if config.Limit <= 0 {
+		panic(fmt.Sprintf("ratelimit: invalid sliding window limit %d", config.Limit))
+	}
+
+	if config.Store != nil && config.Name == "" {
+		panic("ratelimit: sliding window with a Store needs a Name")
+	}
+
+	cfg := SlidingWindowConfig{
+		Name:   config.Name,
+		Limit:  config.Limit,
+		Window: time.Minute,
+		Store:  config.Store,
+		Now:    config.Now,
+	}
+
+	if config.Window > 0 {
+		cfg.Window = config.Window
+	}
+
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	if cfg.Store == nil {
+		cfg.Name = "sliding-window"
+
+		cfg.Store = NewMemoryStore(MemoryStoreConfig{Now: cfg.Now})
+	}
+
+	return &SlidingWindow{config: cfg}
+}
+
+type SlidingWindowConfig struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Store  Store
+	Now    func() time.Time
+}
+
+type SlidingWindow struct {
+	config SlidingWindowConfig
+}
+
+func (sw *SlidingWindow) Allow(ctx context.Context, key string) (Result, error) {
+	cfg := sw.config
+
+	now := cfg.Now()
+
+	start := now.Truncate(cfg.Window)
+
+	limit := float64(cfg.Limit)
+
+	result := Result{Limit: cfg.Limit, Window: cfg.Window}
+
+	err := cfg.Store.Update(ctx, cfg.Name+":"+key, 2*cfg.Window, func(state *State) {
+		if !state.Time.Equal(start) {
+			previous := 0.0
+
+			if state.Time.Equal(start.Add(-cfg.Window)) {
+				previous = state.Value
+			}
+
+			state.Value, state.Previous, state.Time = 0, previous, start
+		}
+
+		elapsed := now.Sub(start)
+
+		weight := 1 - elapsed.Seconds()/cfg.Window.Seconds()
+
+		estimate := state.Previous*weight + state.Value
+
+		if estimate+1 <= limit {
+			result.Allowed = true
+
+			state.Value += 1
+
+			estimate += 1
+		} else {
+			result.RetryAfter = sw.retryAfter(state, elapsed)
+		}
+
+		result.Remaining = int(math.Max(0, math.Floor(limit-estimate)))
+
+		result.Reset = cfg.Window - elapsed
+	})
+
+	return result, err
+}
+
+func (sw *SlidingWindow) retryAfter(state *State, elapsed time.Duration) time.Duration {
+	window := sw.config.Window.Seconds()
+
+	room := float64(sw.config.Limit) - 1
+
+	if state.Value <= room && state.Previous > 0 {
+		at := window * (1 - (room-state.Value)/state.Previous)
+
+		return seconds(at - elapsed.Seconds())
+	}
+
+	at := window * (1 - room/state.Value)
+
+	return seconds(window - elapsed.Seconds() + at)
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(math.Ceil(value * float64(time.Second)))
+}
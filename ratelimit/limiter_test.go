@@ -0,0 +1,207 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestTokenBucket(t *testing.T) {
+	t.Parallel()
+
+	type step struct {
+		advance time.Duration
+		want    Result
+	}
+
+	tests := []struct {
+		name   string
+		config TokenBucketConfig
+		steps  []step
+	}{
+		{
+			name:   "should allow burst and then refill",
+			config: TokenBucketConfig{Rate: 1, Burst: 2},
+			steps: []step{
+				{
+					want: Result{Allowed: true, Limit: 2, Remaining: 1, Window: 2 * time.Second, Reset: time.Second},
+				},
+				{
+					want: Result{Allowed: true, Limit: 2, Remaining: 0, Window: 2 * time.Second, Reset: 2 * time.Second},
+				},
+				{
+					want: Result{Allowed: false, Limit: 2, Remaining: 0, Window: 2 * time.Second, Reset: 2 * time.Second, RetryAfter: time.Second},
+				},
+				{
+					advance: 500 * time.Millisecond,
+					want:    Result{Allowed: false, Limit: 2, Remaining: 0, Window: 2 * time.Second, Reset: 1500 * time.Millisecond, RetryAfter: 500 * time.Millisecond},
+				},
+				{
+					advance: 500 * time.Millisecond,
+					want:    Result{Allowed: true, Limit: 2, Remaining: 0, Window: 2 * time.Second, Reset: 2 * time.Second},
+				},
+			},
+		},
+		{
+			name:   "should refill at rate per period",
+			config: TokenBucketConfig{Rate: 10, Per: time.Minute},
+			steps: []step{
+				{
+					want: Result{Allowed: true, Limit: 10, Remaining: 9, Window: time.Minute, Reset: 6 * time.Second},
+				},
+				{
+					advance: time.Hour,
+					want:    Result{Allowed: true, Limit: 10, Remaining: 9, Window: time.Minute, Reset: 6 * time.Second},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		c := &clock{now: time.Unix(1700000000, 0)}
+
+		config := tt.config
+
+		config.Now = c.Now
+
+		limiter := NewTokenBucket(config)
+
+		for i, s := range tt.steps {
+			c.Advance(s.advance)
+
+			got, err := limiter.Allow(context.Background(), "key")
+
+			assert.NoError(t, err)
+
+			assert.Equalf(t, s.want, got, "%s step %d: want %v, got %v", tt.name, i, s.want, got)
+		}
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{now: time.Unix(1700000000, 0).Truncate(time.Minute)}
+
+	limiter := NewSlidingWindow(SlidingWindowConfig{Limit: 4, Window: time.Minute, Now: c.Now})
+
+	type step struct {
+		advance time.Duration
+		want    Result
+	}
+
+	steps := []step{
+		{
+			want: Result{Allowed: true, Limit: 4, Remaining: 3, Window: time.Minute, Reset: time.Minute},
+		},
+		{
+			want: Result{Allowed: true, Limit: 4, Remaining: 2, Window: time.Minute, Reset: time.Minute},
+		},
+		{
+			want: Result{Allowed: true, Limit: 4, Remaining: 1, Window: time.Minute, Reset: time.Minute},
+		},
+		{
+			want: Result{Allowed: true, Limit: 4, Remaining: 0, Window: time.Minute, Reset: time.Minute},
+		},
+		{
+			advance: 30 * time.Second,
+			want:    Result{Allowed: false, Limit: 4, Remaining: 0, Window: time.Minute, Reset: 30 * time.Second, RetryAfter: 45 * time.Second},
+		},
+		{
+			advance: 45 * time.Second,
+			want:    Result{Allowed: true, Limit: 4, Remaining: 0, Window: time.Minute, Reset: 45 * time.Second},
+		},
+		{
+			want: Result{Allowed: false, Limit: 4, Remaining: 0, Window: time.Minute, Reset: 45 * time.Second, RetryAfter: 15 * time.Second},
+		},
+		{
+			advance: 2 * time.Minute,
+			want:    Result{Allowed: true, Limit: 4, Remaining: 3, Window: time.Minute, Reset: 45 * time.Second},
+		},
+	}
+
+	for i, s := range steps {
+		c.Advance(s.advance)
+
+		got, err := limiter.Allow(context.Background(), "key")
+
+		assert.NoError(t, err)
+
+		assert.Equalf(t, s.want, got, "step %d: want %v, got %v", i, s.want, got)
+	}
+}
+
+func TestNewTokenBucket_Invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "ratelimit: invalid token bucket rate 0", func() {
+		NewTokenBucket(TokenBucketConfig{})
+	})
+
+	assert.PanicsWithValue(t, "ratelimit: invalid sliding window limit 0", func() {
+		NewSlidingWindow(SlidingWindowConfig{})
+	})
+
+	store := NewMemoryStore(MemoryStoreConfig{})
+
+	assert.PanicsWithValue(t, "ratelimit: token bucket with a Store needs a Name", func() {
+		NewTokenBucket(TokenBucketConfig{Rate: 1, Store: store})
+	})
+
+	assert.PanicsWithValue(t, "ratelimit: sliding window with a Store needs a Name", func() {
+		NewSlidingWindow(SlidingWindowConfig{Limit: 1, Store: store})
+	})
+}
+
+func TestLimiter_SharedStore(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{now: time.Unix(1700000000, 0)}
+
+	store := NewMemoryStore(MemoryStoreConfig{Now: c.Now})
+
+	tests := []struct {
+		name   string
+		first  Limiter
+		second Limiter
+		keys   [2]string
+	}{
+		{
+			name:   "should keep named limiters apart",
+			first:  NewTokenBucket(TokenBucketConfig{Name: "ip", Rate: 1, Store: store, Now: c.Now}),
+			second: NewSlidingWindow(SlidingWindowConfig{Name: "user", Limit: 1, Store: store, Now: c.Now}),
+			keys:   [2]string{"ip:10.0.0.1", "ip:10.0.0.1"},
+		},
+		{
+			name:   "should separate names from keys",
+			first:  NewTokenBucket(TokenBucketConfig{Name: "a", Rate: 1, Store: store, Now: c.Now}),
+			second: NewTokenBucket(TokenBucketConfig{Name: "ab", Rate: 1, Store: store, Now: c.Now}),
+			keys:   [2]string{"bc", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		first, err := tt.first.Allow(context.Background(), tt.keys[0])
+
+		assert.NoErrorf(t, err, "%s: first err = %v", tt.name, err)
+
+		second, err := tt.second.Allow(context.Background(), tt.keys[1])
+
+		assert.NoErrorf(t, err, "%s: second err = %v", tt.name, err)
+
+		assert.Truef(t, first.Allowed && second.Allowed, "%s: want both allowed, got %v and %v", tt.name, first.Allowed, second.Allowed)
+	}
+}
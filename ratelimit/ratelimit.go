@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"fmt"
+	h "github.com/aakash-rajur/http"
+	"github.com/aakash-rajur/http/problem"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func New(policy Policy) h.Middleware {
+	global := sanePolicy(policy)
+
+	return func(w http.ResponseWriter, r *http.Request, next h.Next) {
+		p := global
+
+		route, ok := h.RouteFromRequest(r)
+
+		if ok {
+			override, found := route.Value(policyKey)
+
+			if found {
+				p = override.(Policy)
+			}
+		}
+
+		if p.Limiter == nil {
+			next(r)
+
+			return
+		}
+
+		key := p.Key(r)
+
+		if key == "" {
+			next(r)
+
+			return
+		}
+
+		result, err := p.Limiter.Allow(r.Context(), key)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(p.Output, "ratelimit: %v | %s %s\n", err, r.Method, r.URL.Path)
+
+			if p.FailClosed {
+				h.HandleError(w, r, &h.HTTPError{Code: http.StatusServiceUnavailable, Err: err})
+
+				return
+			}
+
+			next(r)
+
+			return
+		}
+
+		writeHeaders(w.Header(), result)
+
+		if result.Allowed {
+			next(r)
+
+			return
+		}
+
+		w.Header().Set("Retry-After", strconv.FormatInt(ceilSeconds(result.RetryAfter), 10))
+
+		h.RenderError(w, r, problem.New(http.StatusTooManyRequests, ""))
+	}
+}
+
+func WithPolicy(policy Policy) h.RouteOption {
+	return h.WithMetadata(policyKey, sanePolicy(policy))
+}
+
+func sanePolicy(in Policy) Policy {
+	out := Policy{
+		Limiter:    in.Limiter,
+		Key:        ByIP,
+		FailClosed: in.FailClosed,
+		Output:     os.Stderr,
+	}
+
+	if in.Key != nil {
+		out.Key = in.Key
+	}
+
+	if in.Output != nil {
+		out.Output = in.Output
+	}
+
+	return out
+}
+
+type Policy struct {
+	Limiter    Limiter
+	Key        KeyFunc
+	FailClosed bool
+	Output     io.Writer
+}
+
+func writeHeaders(header http.Header, result Result) {
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+
+	header.Set("RateLimit-Reset", strconv.FormatInt(ceilSeconds(result.Reset), 10))
+
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", result.Limit, ceilSeconds(result.Window)))
+}
+
+func ceilSeconds(duration time.Duration) int64 {
+	return int64(math.Ceil(duration.Seconds()))
+}
+
+const policyKey = "http_ratelimit_policy"
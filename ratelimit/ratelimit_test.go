@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	h "github.com/aakash-rajur/http"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	return Result{}, errors.New("store unavailable")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{now: time.Unix(1700000000, 0)}
+
+	router := h.NewRouter()
+
+	router.Use(New(Policy{
+		Limiter: NewTokenBucket(TokenBucketConfig{Rate: 1, Burst: 2, Now: c.Now}),
+	}))
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	router.GetFunc("/books", ok)
+
+	router.GetFunc("/health", ok, WithPolicy(Policy{}))
+
+	logs := new(bytes.Buffer)
+
+	router.GetFunc("/degraded", ok, WithPolicy(Policy{Limiter: failingLimiter{}, Output: logs}))
+
+	router.GetFunc("/closed", ok, WithPolicy(Policy{Limiter: failingLimiter{}, FailClosed: true, Output: logs}))
+
+	api := router.Group("/api", WithPolicy(Policy{
+		Limiter: NewSlidingWindow(SlidingWindowConfig{Limit: 1, Window: time.Minute, Now: c.Now}),
+		Key:     ByHeader("X-API-Key"),
+	}))
+
+	api.GetFunc("/books", ok)
+
+	tests := []struct {
+		name        string
+		target      string
+		remoteAddr  string
+		headers     map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:       "should allow first request",
+			target:     "/books",
+			remoteAddr: "10.0.0.1:1234",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "2",
+				"RateLimit-Remaining": "1",
+				"RateLimit-Reset":     "1",
+				"RateLimit-Policy":    "2;w=2",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:       "should allow burst",
+			target:     "/books",
+			remoteAddr: "10.0.0.1:4321",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "2",
+			},
+		},
+		{
+			name:       "should reject once exhausted",
+			target:     "/books",
+			remoteAddr: "10.0.0.1:1234",
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"Retry-After":         "1",
+				"Content-Type":        "application/problem+json",
+			},
+		},
+		{
+			name:       "should track clients separately",
+			target:     "/books",
+			remoteAddr: "10.0.0.2:1234",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "1",
+			},
+		},
+		{
+			name:       "should skip routes without limiter",
+			target:     "/health",
+			remoteAddr: "10.0.0.1:1234",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+		{
+			name:       "should fail open on limiter error",
+			target:     "/degraded",
+			remoteAddr: "10.0.0.1:1234",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+		{
+			name:       "should fail closed when configured",
+			target:     "/closed",
+			remoteAddr: "10.0.0.1:1234",
+			wantStatus: http.StatusServiceUnavailable,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit": "",
+				"Content-Type":    "application/problem+json",
+			},
+		},
+		{
+			name:       "should apply group policy by api key",
+			target:     "/api/books",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-API-Key": "secret"},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "1",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Policy":    "1;w=60",
+			},
+		},
+		{
+			name:       "should reject same api key",
+			target:     "/api/books",
+			remoteAddr: "10.0.0.3:1234",
+			headers:    map[string]string{"X-API-Key": "secret"},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"Retry-After": "100",
+			},
+		},
+		{
+			name:       "should skip requests without api key",
+			target:     "/api/books",
+			remoteAddr: "10.0.0.1:1234",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+		req.RemoteAddr = tt.remoteAddr
+
+		for key, value := range tt.headers {
+			req.Header.Set(key, value)
+		}
+
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equalf(t, tt.wantStatus, rec.Code, "%s: want %v, got %v", tt.name, tt.wantStatus, rec.Code)
+
+		for key, want := range tt.wantHeaders {
+			got := rec.Header().Get(key)
+
+			assert.Equalf(t, want, got, "%s: %s want %v, got %v", tt.name, key, want, got)
+		}
+	}
+
+	wantLogs := "ratelimit: store unavailable | GET /degraded\nratelimit: store unavailable | GET /closed\n"
+
+	assert.Equalf(t, wantLogs, logs.String(), "want %v, got %v", wantLogs, logs.String())
+}
+
+func TestKeyFunc(t *testing.T) {
+	t.Parallel()
+
+	router := h.NewRouter()
+
+	var got []string
+
+	user := ByUser(func(r *http.Request) string {
+		return r.Header.Get("X-User")
+	})
+
+	key := First(user, Join(ByRoute, ByIP))
+
+	router.GetFunc("/books/:id", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, key(r))
+	})
+
+	requests := []map[string]string{
+		{"X-User": "42"},
+		{},
+	}
+
+	for _, headers := range requests {
+		req := httptest.NewRequest(http.MethodGet, "/books/1", nil)
+
+		req.RemoteAddr = "[::1]:8080"
+
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	want := []string{"user:42", "route:GET /books/:id|ip:::1"}
+
+	assert.Equalf(t, want, got, "want %v, got %v", want, got)
+
+	gotHeader := ByHeader("X-API-Key")(httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equalf(t, "", gotHeader, "want %v, got %v", "", gotHeader)
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+type State struct {
+	Value    float64
+	Previous float64
+	Time     time.Time
+}
+
+type Store interface {
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error
+}
+
+func NewMemoryStore(config MemoryStoreConfig) *MemoryStore {
+	cfg := saneMemoryStoreConfig(config)
+
+	store := &MemoryStore{
+		config: cfg,
+		shards: make([]*shard, cfg.Shards),
+	}
+
+	for i := range store.shards {
+		store.shards[i] = &shard{entries: make(map[string]*entry)}
+	}
+
+	return store
+}
+
+func saneMemoryStoreConfig(in MemoryStoreConfig) MemoryStoreConfig {
+	out := MemoryStoreConfig{
+		Shards:        32,
+		SweepInterval: time.Minute,
+		Now:           time.Now,
+	}
+
+	if in.Shards > 0 {
+		out.Shards = in.Shards
+	}
+
+	if in.SweepInterval > 0 {
+		out.SweepInterval = in.SweepInterval
+	}
+
+	if in.Now != nil {
+		out.Now = in.Now
+	}
+
+	return out
+}
+
+type MemoryStoreConfig struct {
+	Shards        int
+	SweepInterval time.Duration
+	Now           func() time.Time
+}
+
+type MemoryStore struct {
+	config MemoryStoreConfig
+	shards []*shard
+}
+
+type shard struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+type entry struct {
+	state   State
+	expires time.Time
+}
+
+func (store *MemoryStore) Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	err := ctx.Err()
+
+	if err != nil {
+		return err
+	}
+
+	now := store.config.Now()
+
+	s := store.shardOf(key)
+
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= store.config.SweepInterval {
+		s.sweep(now)
+	}
+
+	e, ok := s.entries[key]
+
+	if !ok || !now.Before(e.expires) {
+		e = &entry{}
+
+		s.entries[key] = e
+	}
+
+	fn(&e.state)
+
+	e.expires = now.Add(ttl)
+
+	return nil
+}
+
+func (store *MemoryStore) Len() int {
+	count := 0
+
+	for _, s := range store.shards {
+		s.mu.Lock()
+
+		count += len(s.entries)
+
+		s.mu.Unlock()
+	}
+
+	return count
+}
+
+func (store *MemoryStore) shardOf(key string) *shard {
+	hash := fnv.New32a()
+
+	_, _ = hash.Write([]byte(key))
+
+	return store.shards[hash.Sum32()%uint32(len(store.shards))]
+}
+
+func (s *shard) sweep(now time.Time) {
+	for key, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, key)
+		}
+	}
+
+	s.lastSweep = now
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+
+	store := NewMemoryStore(MemoryStoreConfig{
+		Shards:        1,
+		SweepInterval: time.Minute,
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	increment := func(key string, ttl time.Duration) float64 {
+		var value float64
+
+		err := store.Update(context.Background(), key, ttl, func(state *State) {
+			state.Value += 1
+
+			value = state.Value
+		})
+
+		assert.NoError(t, err)
+
+		return value
+	}
+
+	got := []float64{
+		increment("a", time.Second),
+		increment("a", time.Second),
+		increment("b", 2*time.Minute),
+	}
+
+	want := []float64{1, 2, 1}
+
+	assert.Equalf(t, want, got, "want %v, got %v", want, got)
+
+	now = now.Add(time.Second)
+
+	gotExpired := increment("a", time.Second)
+
+	assert.Equalf(t, 1.0, gotExpired, "want %v, got %v", 1.0, gotExpired)
+
+	now = now.Add(90 * time.Second)
+
+	increment("c", time.Second)
+
+	gotLen := store.Len()
+
+	assert.Equalf(t, 2, gotLen, "want %v, got %v", 2, gotLen)
+}
+
+func TestMemoryStore_Canceled(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore(MemoryStoreConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	err := store.Update(ctx, "a", time.Second, func(state *State) {
+		t.Fatal("update should not run")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/problem"
+	"io"
+	"net/http"
+	"os"
+	"runtime/debug"
+)
+
+func Recover(config RecoverConfig) Middleware {
+	cfg := saneRecoverConfig(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		defer func() {
+			value := recover()
+
+			if value == nil {
+				return
+			}
+
+			recoverPanic(cfg, w, r, value)
+		}()
+
+		next(r)
+	}
+}
+
+func saneRecoverConfig(in RecoverConfig) RecoverConfig {
+	out := RecoverConfig{
+		Output:  os.Stderr,
+		OnPanic: in.OnPanic,
+	}
+
+	if in.Output != nil {
+		out.Output = in.Output
+	}
+
+	return out
+}
+
+func recoverPanic(cfg RecoverConfig, w http.ResponseWriter, r *http.Request, value any) {
+	if value == http.ErrAbortHandler {
+		panic(value)
+	}
+
+	p := &Panic{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+
+	route, ok := RouteFromRequest(r)
+
+	if ok {
+		p.Route = route
+	}
+
+	_, _ = fmt.Fprintf(cfg.Output, "panic: %v | %s\n%s\n", p.Value, p.target(r), p.Stack)
+
+	if cfg.OnPanic != nil {
+		cfg.OnPanic(r, p)
+	}
+
+	rw, ok := UnwrapResponseWriter(w)
+
+	if ok {
+		rw.Err = errors.Join(rw.Err, p)
+	}
+
+	if ok && rw.StatusCode != 0 {
+		panic(http.ErrAbortHandler)
+	}
+
+	RenderError(w, r, problem.New(http.StatusInternalServerError, ""))
+}
+
+type RecoverConfig struct {
+	Output  io.Writer
+	OnPanic func(r *http.Request, p *Panic)
+}
+
+type Panic struct {
+	Value any
+	Stack []byte
+	Route *Route
+}
+
+func (p *Panic) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+func (p *Panic) Unwrap() error {
+	err, _ := p.Value.(error)
+
+	return err
+}
+
+func (p *Panic) target(r *http.Request) string {
+	if p.Route != nil {
+		return p.Route.String()
+	}
+
+	return r.Method + " " + r.URL.Path
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantBody   string
+		wantLog    []string
+		wantErr    string
+	}{
+		{
+			name: "should pass through handlers that do not panic",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name: "should render a problem when nothing was written",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				books := make([]string, 0)
+
+				_ = books[len(r.URL.Path)]
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"instance":"/books/7","status":500,"title":"Internal Server Error"}`,
+			wantLog: []string{
+				"panic: runtime error: index out of range [8] with length 0 | GET /books/{id}",
+				"goroutine",
+			},
+			wantErr: "| panic: runtime error: index out of range [8] with length 0",
+		},
+		{
+			name: "should recover non error values",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				panic("unreachable")
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"instance":"/books/7","status":500,"title":"Internal Server Error"}`,
+			wantLog:    []string{"panic: unreachable | GET /books/{id}"},
+			wantErr:    "| panic: unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, output := NewMemoryWriter(), NewMemoryWriter()
+
+			var got *Panic
+
+			router := NewRouter()
+
+			router.Use(Logger(LoggerConfig{Output: logs}))
+
+			router.Use(Recover(RecoverConfig{
+				Output: output,
+				OnPanic: func(r *http.Request, p *Panic) {
+					got = p
+				},
+			}))
+
+			router.GetFunc("/books/{id}", tt.handler)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/7", nil))
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if strings.HasPrefix(tt.wantBody, "{") {
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			} else {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+
+			for _, each := range tt.wantLog {
+				assert.Containsf(t, output.Content, each, "want %v, got %v", each, output.Content)
+			}
+
+			assert.Containsf(t, logs.Content, tt.wantErr, "want %v, got %v", tt.wantErr, logs.Content)
+
+			if len(tt.wantLog) == 0 {
+				assert.Nilf(t, got, "want no panic, got %v", got)
+
+				return
+			}
+
+			assert.Equalf(t, "/books/{id}", got.Route.Pattern, "want /books/{id}, got %v", got.Route)
+
+			assert.NotEmptyf(t, got.Stack, "want a stack, got %v", got.Stack)
+		})
+	}
+}
+
+func TestRecover_Abort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantLog bool
+	}{
+		{
+			name: "should propagate http.ErrAbortHandler untouched",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				panic(http.ErrAbortHandler)
+			},
+			wantLog: false,
+		},
+		{
+			name: "should abort responses that already started",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+
+				panic(errors.New("stream interrupted"))
+			},
+			wantLog: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := NewMemoryWriter()
+
+			called := false
+
+			router := NewRouter()
+
+			router.Use(Recover(RecoverConfig{
+				Output: output,
+				OnPanic: func(r *http.Request, p *Panic) {
+					called = true
+				},
+			}))
+
+			router.GetFunc("/stream", tt.handler)
+
+			w := httptest.NewRecorder()
+
+			assert.PanicsWithValuef(
+				t,
+				http.ErrAbortHandler,
+				func() {
+					router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+				},
+				"want http.ErrAbortHandler",
+			)
+
+			assert.Equalf(t, tt.wantLog, called, "want %v, got %v", tt.wantLog, called)
+
+			assert.Equalf(t, tt.wantLog, output.Content != "", "want %v, got %v", tt.wantLog, output.Content)
+		})
+	}
+}
+
+func TestPanic_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("boom")
+
+	p := &Panic{Value: cause}
+
+	assert.ErrorIsf(t, p, cause, "want %v, got %v", cause, p)
+
+	assert.Equalf(t, "panic: boom", p.Error(), "want panic: boom, got %v", p.Error())
+
+	assert.Nilf(t, (&Panic{Value: 42}).Unwrap(), "want nil for non error values")
+}
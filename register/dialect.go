@@ -0,0 +1,45 @@
+package register
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Dialect int
+
+const (
+	DialectBraces Dialect = 1 << iota
+	DialectColon
+	DialectBoth = DialectBraces | DialectColon
+)
+
+func Normalize(pattern string, dialect Dialect) string {
+	partials := strings.Split(pattern, "/")
+
+	for i, partial := range partials {
+		partials[i] = normalizeSegment(partial, dialect)
+	}
+
+	return strings.Join(partials, "/")
+}
+
+func normalizeSegment(partial string, dialect Dialect) string {
+	s := segment(partial)
+
+	if s.isParam() && s != anchor && dialect&DialectBraces == 0 {
+		panic(fmt.Sprintf("register: %s uses {name} syntax which is disabled by dialect", partial))
+	}
+
+	if dialect&DialectColon == 0 || len(partial) < 2 {
+		return partial
+	}
+
+	switch partial[0] {
+	case ':':
+		return "{" + partial[1:] + "}"
+	case '*':
+		return "{" + partial[1:] + "...}"
+	default:
+		return partial
+	}
+}
@@ -0,0 +1,78 @@
+package register
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pattern string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name:    "should keep braces",
+			pattern: "/api/v2/books/{id}",
+			dialect: DialectBraces,
+			want:    "/api/v2/books/{id}",
+		},
+		{
+			name:    "should keep colon literal in braces dialect",
+			pattern: "/api/v2/books/:id",
+			dialect: DialectBraces,
+			want:    "/api/v2/books/:id",
+		},
+		{
+			name:    "should convert colon params",
+			pattern: "/repos/:owner/:repo",
+			dialect: DialectColon,
+			want:    "/repos/{owner}/{repo}",
+		},
+		{
+			name:    "should convert catch-all",
+			pattern: "/static/*filepath",
+			dialect: DialectColon,
+			want:    "/static/{filepath...}",
+		},
+		{
+			name:    "should keep bare star",
+			pattern: "/static/*",
+			dialect: DialectColon,
+			want:    "/static/*",
+		},
+		{
+			name:    "should mix dialects",
+			pattern: "/users/{userId}/books/:bookId/*rest",
+			dialect: DialectBoth,
+			want:    "/users/{userId}/books/{bookId}/{rest...}",
+		},
+		{
+			name:    "should keep colon inside segment",
+			pattern: "/v1/{name}:cancel",
+			dialect: DialectBoth,
+			want:    "/v1/{name}:cancel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Normalize(tt.pattern, tt.dialect)
+
+			assert.Equalf(t, tt.want, got, "Normalize() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestNormalize_DisabledBraces(t *testing.T) {
+	t.Parallel()
+
+	assert.Panicsf(
+		t,
+		func() { Normalize("/books/{id}", DialectColon) },
+		"should panic when braces are disabled",
+	)
+}
@@ -5,6 +5,11 @@ import "net/http"
 type Entry struct {
 	segments segments
 	Handler  http.Handler
+	Value    any
+}
+
+func (e Entry) Pattern() string {
+	return "/" + e.segments.String()
 }
 
 func (e Entry) cmp(other Entry) int {
@@ -25,13 +30,13 @@ func (e Entry) cmp(other Entry) int {
 
 		segmentA, segmentB := a[i], b[i]
 
-		aParam, bParam := segmentA.isParam(), segmentB.isParam()
+		aKind, bKind := segmentA.kind(), segmentB.kind()
 
-		if aParam && !bParam {
+		if aKind > bKind {
 			return 1
 		}
 
-		if !aParam && bParam {
+		if aKind < bKind {
 			return -1
 		}
 
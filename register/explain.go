@@ -0,0 +1,186 @@
+package register
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func (r Register) Explain(pattern string) Explanation {
+	safePath := cleanPath(pattern)
+
+	ss := segmentsFromPath(safePath)
+
+	explanation := Explanation{
+		Path:       safePath,
+		Steps:      make([]Step, 0),
+		Matches:    make([]string, 0),
+		Candidates: make([]Candidate, 0),
+	}
+
+	trace := tracer(func(step Step) { explanation.Steps = append(explanation.Steps, step) })
+
+	for _, index := range r.search(ss, 0, 0, len(r), make([]int, 0), trace) {
+		explanation.Matches = append(explanation.Matches, r[index].Pattern())
+	}
+
+	explanation.Candidates = r.candidates(ss, maxCandidates)
+
+	return explanation
+}
+
+func (r Register) candidates(ss segments, limit int) []Candidate {
+	candidates := make([]Candidate, 0, len(r))
+
+	seen := make(map[string]bool)
+
+	for _, entry := range r {
+		pattern := entry.Pattern()
+
+		if seen[pattern] {
+			continue
+		}
+
+		seen[pattern] = true
+
+		score, reason := entry.segments.explain(ss)
+
+		candidates = append(
+			candidates,
+			Candidate{
+				Pattern:  pattern,
+				Score:    score,
+				Rejected: reason,
+			},
+		)
+	}
+
+	slices.SortStableFunc(candidates, func(a, b Candidate) int { return b.Score - a.Score })
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates
+}
+
+func (s segments) explain(other segments) (int, string) {
+	for index, each := range s {
+		if each.isCatchAll() {
+			return len(other) + 1, ""
+		}
+
+		if index >= len(other) {
+			return index, fmt.Sprintf("path ends at segment %d, route expects %d segments", index, len(s))
+		}
+
+		if each.isParam() || each == other[index] {
+			continue
+		}
+
+		return index, fmt.Sprintf("segment %d %q does not match %q", index, other[index], each)
+	}
+
+	if len(other) > len(s) {
+		return len(s), fmt.Sprintf("path has %d segments, route expects %d", len(other), len(s))
+	}
+
+	return len(s) + 1, ""
+}
+
+type Explanation struct {
+	Path       string      `json:"path" yaml:"path"`
+	Steps      []Step      `json:"steps" yaml:"steps"`
+	Matches    []string    `json:"matches" yaml:"matches"`
+	Candidates []Candidate `json:"candidates" yaml:"candidates"`
+}
+
+func (e Explanation) String() string {
+	lines := []string{fmt.Sprintf("path: %s", e.Path), "steps:"}
+
+	for _, step := range e.Steps {
+		lines = append(lines, "  "+step.String())
+	}
+
+	lines = append(lines, "matches:")
+
+	if len(e.Matches) == 0 {
+		lines = append(lines, "  none")
+	}
+
+	for _, match := range e.Matches {
+		lines = append(lines, "  "+match)
+	}
+
+	lines = append(lines, "closest:")
+
+	for _, candidate := range e.Candidates {
+		reason := candidate.Rejected
+
+		if reason == "" {
+			reason = "matches"
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s (%s)", candidate.Pattern, reason))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type Step struct {
+	Depth   int    `json:"depth" yaml:"depth"`
+	Kind    string `json:"kind" yaml:"kind"`
+	Segment string `json:"segment" yaml:"segment"`
+	Left    int    `json:"left" yaml:"left"`
+	Right   int    `json:"right" yaml:"right"`
+}
+
+func (s Step) String() string {
+	indent := strings.Repeat("  ", s.Depth)
+
+	if s.Kind == "enter" {
+		return fmt.Sprintf("%sdepth %d: %d candidates [%d, %d) for %q", indent, s.Depth, s.Right-s.Left, s.Left, s.Right, s.Segment)
+	}
+
+	return fmt.Sprintf("%s%s %q narrowed to %d candidates [%d, %d)", indent, s.Kind, s.Segment, s.Right-s.Left, s.Left, s.Right)
+}
+
+type Candidate struct {
+	Pattern  string `json:"pattern" yaml:"pattern"`
+	Score    int    `json:"score" yaml:"score"`
+	Rejected string `json:"rejected,omitempty" yaml:"rejected,omitempty"`
+}
+
+type tracer func(Step)
+
+func (t tracer) step(depth, left, right int, ss segments) {
+	if t == nil {
+		return
+	}
+
+	s := ""
+
+	if depth < len(ss) {
+		s = string(ss[depth])
+	}
+
+	t(Step{Depth: depth, Kind: "enter", Segment: s, Left: left, Right: right})
+}
+
+func (t tracer) static(depth, left, right int, target segment) {
+	if t == nil {
+		return
+	}
+
+	t(Step{Depth: depth, Kind: "static", Segment: string(target), Left: left, Right: right})
+}
+
+func (t tracer) param(depth, left, right int, name segment) {
+	if t == nil {
+		return
+	}
+
+	t(Step{Depth: depth, Kind: "param", Segment: string(name), Left: left, Right: right})
+}
+
+const maxCandidates = 5
@@ -0,0 +1,65 @@
+package register
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRegister_Explain(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegister().
+		Add("/GET/api/v2/books", nil).
+		Add("/GET/api/v2/books/{bookId}", nil).
+		Add("/GET/api/v2/users/{userId}/books", nil).
+		Add("/POST/api/v2/books", nil)
+
+	tests := []struct {
+		name           string
+		pattern        string
+		wantPath       string
+		wantMatches    []string
+		wantCandidates []Candidate
+	}{
+		{
+			name:        "should explain a match",
+			pattern:     "/GET/api/v2/books/10/",
+			wantPath:    "/GET/api/v2/books/10",
+			wantMatches: []string{"/GET/api/v2/books/{bookId}"},
+			wantCandidates: []Candidate{
+				{Pattern: "/GET/api/v2/books/{bookId}", Score: 6},
+				{Pattern: "/GET/api/v2/books", Score: 4, Rejected: "path has 5 segments, route expects 4"},
+				{Pattern: "/GET/api/v2/users/{userId}/books", Score: 3, Rejected: `segment 3 "books" does not match "users"`},
+				{Pattern: "/POST/api/v2/books", Score: 0, Rejected: `segment 0 "GET" does not match "POST"`},
+			},
+		},
+		{
+			name:        "should explain a miss",
+			pattern:     "/GET/api/v2/users/10",
+			wantPath:    "/GET/api/v2/users/10",
+			wantMatches: []string{},
+			wantCandidates: []Candidate{
+				{Pattern: "/GET/api/v2/users/{userId}/books", Score: 5, Rejected: "path ends at segment 5, route expects 6 segments"},
+				{Pattern: "/GET/api/v2/books", Score: 3, Rejected: `segment 3 "users" does not match "books"`},
+				{Pattern: "/GET/api/v2/books/{bookId}", Score: 3, Rejected: `segment 3 "users" does not match "books"`},
+				{Pattern: "/POST/api/v2/books", Score: 0, Rejected: `segment 0 "GET" does not match "POST"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Explain(tt.pattern)
+
+			assert.Equalf(t, tt.wantPath, got.Path, "Explain() path = %v, want %v", got.Path, tt.wantPath)
+
+			assert.Equalf(t, tt.wantMatches, got.Matches, "Explain() matches = %v, want %v", got.Matches, tt.wantMatches)
+
+			assert.Equalf(t, tt.wantCandidates, got.Candidates, "Explain() candidates = %v, want %v", got.Candidates, tt.wantCandidates)
+
+			assert.NotEmptyf(t, got.Steps, "Explain() should record steps")
+
+			assert.Containsf(t, got.String(), tt.wantPath, "String() should contain path")
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	p "github.com/aakash-rajur/http/params"
 	"net/http"
 	"slices"
+	"sort"
+	"strings"
 )
 
 func NewRegister() Register {
@@ -14,11 +16,16 @@ func NewRegister() Register {
 type Register []Entry
 
 func (r Register) Add(pattern string, handler http.Handler) Register {
+	return r.AddValue(pattern, handler, nil)
+}
+
+func (r Register) AddValue(pattern string, handler http.Handler, value any) Register {
 	ss := segmentsFromPath(pattern)
 
 	entry := Entry{
 		segments: ss,
 		Handler:  handler,
+		Value:    value,
 	}
 
 	updated := append(r, entry)
@@ -33,41 +40,126 @@ func (r Register) Find(pattern string) (Entry, p.Params, error) {
 		return Entry{}, p.Params{}, ErrNotFound
 	}
 
+	matches, err := r.FindAll(pattern)
+
+	if err != nil {
+		return Entry{}, nil, err
+	}
+
+	return matches[0].Entry, matches[0].Params, nil
+}
+
+func (r Register) FindAll(pattern string) ([]Match, error) {
 	safePath := cleanPath(pattern)
 
 	ss := segmentsFromPath(safePath)
 
-	left, right := 0, len(r)-1
+	indices := r.search(ss, 0, 0, len(r), make([]int, 0), nil)
+
+	if len(indices) == 0 {
+		return nil, ErrNotFound
+	}
+
+	matches := make([]Match, 0, len(indices))
 
-	for left <= right {
-		mid := left + (right-left)/2
+	for _, index := range indices {
+		entry := r[index]
 
-		entry := r[mid]
+		matches = append(
+			matches,
+			Match{
+				Entry:  entry,
+				Params: entry.segments.params(ss),
+			},
+		)
+	}
 
-		comparison := entry.segments.cmp(ss)
+	return matches, nil
+}
 
-		if comparison == 0 {
-			params := entry.segments.params(ss)
+// search walks the sorted entries one segment at a time, every entry within
+// [left, right) shares the first depth segments and matches them. static
+// segments are binary searched, params and catch-alls are visited after so
+// the most specific entries are collected first.
+func (r Register) search(ss segments, depth, left, right int, found []int, trace tracer) []int {
+	trace.step(depth, left, right, ss)
 
-			return entry, params, nil
+	for left < right && len(r[left].segments) == depth {
+		if depth == len(ss) {
+			found = append(found, left)
 		}
 
-		// mid > target, go left
-		if comparison > 0 {
-			right = mid - 1
+		left += 1
+	}
+
+	if depth == len(ss) {
+		for index := left; index < right; index += 1 {
+			entry := r[index].segments
 
-			continue
+			if len(entry) == depth+1 && entry[depth].isCatchAll() {
+				found = append(found, index)
+			}
 		}
 
-		// mid < target, go right
-		if comparison < 0 {
-			left = mid + 1
+		return found
+	}
+
+	target := ss[depth]
+
+	staticLeft := left + sort.Search(right-left, func(i int) bool {
+		return r.compareAt(left+i, depth, kindStatic, target) >= 0
+	})
+
+	staticRight := left + sort.Search(right-left, func(i int) bool {
+		return r.compareAt(left+i, depth, kindStatic, target) > 0
+	})
 
-			continue
+	trace.static(depth, staticLeft, staticRight, target)
+
+	found = r.search(ss, depth+1, staticLeft, staticRight, found, trace)
+
+	index := left + sort.Search(right-left, func(i int) bool {
+		return r[left+i].segments[depth].kind() >= kindParam
+	})
+
+	for index < right && r[index].segments[depth].kind() == kindParam {
+		name := r[index].segments[depth]
+
+		blockRight := index + sort.Search(right-index, func(i int) bool {
+			return r.compareAt(index+i, depth, kindParam, name) > 0
+		})
+
+		trace.param(depth, index, blockRight, name)
+
+		found = r.search(ss, depth+1, index, blockRight, found, trace)
+
+		index = blockRight
+	}
+
+	for ; index < right; index += 1 {
+		if len(r[index].segments) == depth+1 {
+			found = append(found, index)
 		}
 	}
 
-	return Entry{}, nil, ErrNotFound
+	return found
+}
+
+func (r Register) compareAt(index, depth, kind int, target segment) int {
+	candidate := r[index].segments[depth]
+
+	candidateKind := candidate.kind()
+
+	if candidateKind != kind {
+		return candidateKind - kind
+	}
+
+	return strings.Compare(string(candidate), string(target))
+}
+
+type Match struct {
+	Entry
+	Params p.Params
 }
 
 var ErrNotFound = errors.New("entry not found")
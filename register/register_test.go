@@ -460,6 +460,160 @@ func TestRegister_Find(t *testing.T) {
 	}
 }
 
+func TestRegister_FindAll(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegister().
+		AddValue("/api/{id}", nil, "param").
+		AddValue("/api/books", nil, "static").
+		AddValue("/api/books", nil, "static-2").
+		AddValue("/health", nil, "health")
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []any
+		err     error
+	}{
+		{
+			name:    "should return duplicates in registration order",
+			pattern: "/api/books",
+			want:    []any{"static", "static-2", "param"},
+		},
+		{
+			name:    "should return param routes",
+			pattern: "/api/v2",
+			want:    []any{"param"},
+		},
+		{
+			name:    "should return param routes for numeric segments",
+			pattern: "/api/10",
+			want:    []any{"param"},
+		},
+		{
+			name:    "should return single match",
+			pattern: "/health",
+			want:    []any{"health"},
+		},
+		{
+			name:    "should return not found",
+			pattern: "/missing",
+			err:     ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := r.FindAll(tt.pattern)
+
+			assert.Equalf(t, tt.err, err, "FindAll() err = %v, want %v", err, tt.err)
+
+			if err != nil {
+				return
+			}
+
+			got := make([]any, 0, len(matches))
+
+			for _, match := range matches {
+				got = append(got, match.Value)
+			}
+
+			assert.Equalf(t, tt.want, got, "FindAll() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestRegister_Find_Precedence(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegister().
+		AddValue("/books/{id}", nil, "/books/{id}").
+		AddValue("/books/new", nil, "/books/new").
+		AddValue("/books/{id}/reviews", nil, "/books/{id}/reviews").
+		AddValue("/{section}/{id}/reviews", nil, "/{section}/{id}/reviews").
+		AddValue("/static/{path...}", nil, "/static/{path...}").
+		AddValue("/static/css/{file}", nil, "/static/css/{file}").
+		AddValue("/{$}", nil, "/{$}")
+
+	tests := []struct {
+		name       string
+		pattern    string
+		wantValue  any
+		wantParams params.Params
+		wantErr    error
+	}{
+		{
+			name:       "should prefer static over param",
+			pattern:    "/books/new",
+			wantValue:  "/books/new",
+			wantParams: params.Params{},
+		},
+		{
+			name:       "should match param",
+			pattern:    "/books/10",
+			wantValue:  "/books/{id}",
+			wantParams: params.Params{"id": "10"},
+		},
+		{
+			name:       "should backtrack from static into param",
+			pattern:    "/books/new/reviews",
+			wantValue:  "/books/{id}/reviews",
+			wantParams: params.Params{"id": "new"},
+		},
+		{
+			name:       "should backtrack to leading param",
+			pattern:    "/authors/10/reviews",
+			wantValue:  "/{section}/{id}/reviews",
+			wantParams: params.Params{"section": "authors", "id": "10"},
+		},
+		{
+			name:       "should capture remainder in catch-all",
+			pattern:    "/static/js/vendor/app.js",
+			wantValue:  "/static/{path...}",
+			wantParams: params.Params{"path": "js/vendor/app.js"},
+		},
+		{
+			name:       "should prefer param over catch-all",
+			pattern:    "/static/css/site.css",
+			wantValue:  "/static/css/{file}",
+			wantParams: params.Params{"file": "site.css"},
+		},
+		{
+			name:       "should fall back to catch-all",
+			pattern:    "/static/css/a/b.css",
+			wantValue:  "/static/{path...}",
+			wantParams: params.Params{"path": "css/a/b.css"},
+		},
+		{
+			name:       "should match anchored root",
+			pattern:    "/",
+			wantValue:  "/{$}",
+			wantParams: params.Params{},
+		},
+		{
+			name:    "should not match unknown",
+			pattern: "/authors/10",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, got, err := r.Find(tt.pattern)
+
+			assert.Equalf(t, tt.wantErr, err, "Find() err = %v, want %v", err, tt.wantErr)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equalf(t, tt.wantValue, entry.Value, "Find() entry = %v, want %v", entry.Value, tt.wantValue)
+
+			assert.Equalf(t, tt.wantParams, got, "Find() params = %v, want %v", got, tt.wantParams)
+		})
+	}
+}
+
 func BenchmarkRegister_Find(b *testing.B) {
 	r := NewRegister().
 		Add("/health", http.HandlerFunc(http.NotFound)).
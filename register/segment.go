@@ -22,6 +22,16 @@ func segmentsFromPath(pattern string) segments {
 		ss[i] = segment(partial)
 	}
 
+	last := len(ss) - 1
+
+	if last > -1 && ss[last] == anchor {
+		ss = ss[:last]
+	}
+
+	if len(ss) == 0 {
+		ss = segments{""}
+	}
+
 	return ss
 }
 
@@ -41,12 +51,34 @@ func (s segments) params(other segments) params.Params {
 
 		key, value := each.name(), other[index]
 
+		if each.isCatchAll() {
+			p[key] = other[index:].String()
+
+			break
+		}
+
 		p[key] = string(value)
 	}
 
+	for index := len(other); index < len(s); index += 1 {
+		if s[index].isCatchAll() {
+			p[s[index].name()] = ""
+		}
+	}
+
 	return p
 }
 
+func (s segments) String() string {
+	partials := make([]string, len(s))
+
+	for i, each := range s {
+		partials[i] = string(each)
+	}
+
+	return strings.Join(partials, "/")
+}
+
 func (s segments) cmp(other segments) int {
 	sl, ol := len(s), len(other)
 
@@ -79,12 +111,30 @@ func (s segment) isParam() bool {
 	return isParam
 }
 
+func (s segment) isCatchAll() bool {
+	return s.isParam() && strings.HasSuffix(string(s), "...}")
+}
+
+func (s segment) kind() int {
+	if s.isCatchAll() {
+		return kindCatchAll
+	}
+
+	if s.isParam() {
+		return kindParam
+	}
+
+	return kindStatic
+}
+
 func (s segment) name() string {
 	if !s.isParam() {
 		return ""
 	}
 
-	return string(s[1 : len(s)-1])
+	name := string(s[1 : len(s)-1])
+
+	return strings.TrimSuffix(name, "...")
 }
 
 func (s segment) cmp(other segment) int {
@@ -101,6 +151,14 @@ func (s segment) cmp(other segment) int {
 	return cmp.Compare(s, other)
 }
 
+const (
+	kindStatic = iota
+	kindParam
+	kindCatchAll
+)
+
+const anchor = segment("{$}")
+
 func cleanPath(pattern string) string {
 	safePath := path.Clean(pattern)
 
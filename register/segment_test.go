@@ -191,6 +191,13 @@ func Test_segment_name(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"test_segment_name_3",
+			fields{
+				s: "{path...}",
+			},
+			"path",
+		},
 	}
 
 	for _, tt := range tests {
@@ -758,6 +765,16 @@ func Test_segments_params(t *testing.T) {
 				"arg3": "test",
 			},
 		},
+		{
+			name: "test_segments_params_7",
+			s:    segments{"static", "{path...}"},
+			args: args{
+				other: segments{"static", "css", "site.css"},
+			},
+			want: params.Params{
+				"path": "css/site.css",
+			},
+		},
 		{
 			name: "test_segments_params_6",
 			s:    segments{"{arg1}", "{arg2}", "{arg3}"},
@@ -897,6 +914,20 @@ func Test_segmentsFromPath(t *testing.T) {
 			},
 			want: segments{":arg1", ":arg2", ":arg3", ":arg4"},
 		},
+		{
+			name: "test_segmentsFromPath_16",
+			args: args{
+				pattern: "/{$}",
+			},
+			want: segments{""},
+		},
+		{
+			name: "test_segmentsFromPath_17",
+			args: args{
+				pattern: "/static/{$}",
+			},
+			want: segments{"static"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"github.com/aakash-rajur/http/codec"
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var ErrNotAcceptable = errors.New("render: no acceptable media type")
+
+type Renderer struct {
+	Codecs *codec.Registry
+}
+
+func New(codecs *codec.Registry) *Renderer {
+	return &Renderer{Codecs: codecs}
+}
+
+func (renderer *Renderer) Negotiate(w http.ResponseWriter, r *http.Request, status int, value any) error {
+	negotiate.AddVary(w.Header(), "Accept")
+
+	c, ok := renderer.Codecs.NegotiateFor(r.Header.Get("Accept"), value)
+
+	if !ok {
+		mediaTypes := make([]string, 0)
+
+		for _, mediaType := range renderer.Codecs.MediaTypes() {
+			each, _ := renderer.Codecs.Lookup(mediaType)
+
+			if codec.CanEncode(each, value) {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+
+		notAcceptable(w, mediaTypes)
+
+		return ErrNotAcceptable
+	}
+
+	return Encode(w, status, c, value)
+}
+
+func (renderer *Renderer) NotAcceptable(w http.ResponseWriter) {
+	notAcceptable(w, renderer.Codecs.MediaTypes())
+}
+
+func notAcceptable(w http.ResponseWriter, mediaTypes []string) {
+	body := "not acceptable, available: " + strings.Join(mediaTypes, ", ") + "\n"
+
+	_ = write(w, http.StatusNotAcceptable, "text/plain; charset=utf-8", []byte(body))
+}
+
+func Encode(w http.ResponseWriter, status int, c codec.Codec, value any) error {
+	var buf bytes.Buffer
+
+	err := c.Encode(&buf, value)
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return err
+	}
+
+	return write(w, status, c.MediaType(), buf.Bytes())
+}
+
+func Negotiate(w http.ResponseWriter, r *http.Request, status int, value any) error {
+	return Default.Negotiate(w, r, status, value)
+}
+
+func NotAcceptable(w http.ResponseWriter) {
+	Default.NotAcceptable(w)
+}
+
+func JSON(w http.ResponseWriter, status int, value any) error {
+	return Encode(w, status, codec.JSON{}, value)
+}
+
+func XML(w http.ResponseWriter, status int, value any) error {
+	return Encode(w, status, codec.XML{}, value)
+}
+
+func Text(w http.ResponseWriter, status int, text string) error {
+	return write(w, status, "text/plain; charset=utf-8", []byte(text))
+}
+
+func Bytes(w http.ResponseWriter, status int, contentType string, body []byte) error {
+	return write(w, status, contentType, body)
+}
+
+func HTML(w http.ResponseWriter, status int, tmpl *template.Template, name string, data any) error {
+	var buf bytes.Buffer
+
+	err := tmpl.ExecuteTemplate(&buf, name, data)
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return err
+	}
+
+	return write(w, status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+func write(w http.ResponseWriter, status int, contentType string, body []byte) error {
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		w.WriteHeader(status)
+
+		return nil
+	}
+
+	header := w.Header()
+
+	header.Set("Content-Type", contentType)
+
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	w.WriteHeader(status)
+
+	_, err := w.Write(body)
+
+	return err
+}
+
+var Default = New(codec.Default)
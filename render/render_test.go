@@ -0,0 +1,211 @@
+package render
+
+import (
+	"errors"
+	"github.com/aakash-rajur/http/codec"
+	"github.com/stretchr/testify/assert"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type book struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		accept      string
+		value       any
+		status      int
+		contentType string
+		body        string
+		wantErr     bool
+	}{
+		{
+			name:        "should default to json without accept",
+			accept:      "",
+			value:       book{Name: "Alchemist"},
+			status:      http.StatusCreated,
+			contentType: "application/json",
+			body:        "{\"name\":\"Alchemist\"}\n",
+		},
+		{
+			name:        "should honour q-values",
+			accept:      "application/json;q=0.4, application/xml;q=0.9",
+			value:       book{Name: "Alchemist"},
+			status:      http.StatusOK,
+			contentType: "application/xml",
+			body:        "<book><name>Alchemist</name></book>",
+		},
+		{
+			name:        "should respond 406 when nothing is acceptable",
+			accept:      "image/png",
+			value:       book{Name: "Alchemist"},
+			status:      http.StatusNotAcceptable,
+			contentType: "text/plain; charset=utf-8",
+			body:        "not acceptable, available: application/json, application/xml, application/x-www-form-urlencoded, application/cbor, application/msgpack\n",
+			wantErr:     true,
+		},
+		{
+			name:        "should only offer codecs that can encode the value",
+			accept:      "application/x-www-form-urlencoded",
+			value:       []string{"Alchemist"},
+			status:      http.StatusNotAcceptable,
+			contentType: "text/plain; charset=utf-8",
+			body:        "not acceptable, available: application/json, application/xml, application/cbor, application/msgpack\n",
+			wantErr:     true,
+		},
+		{
+			name:        "should respond 406 for maps as xml",
+			accept:      "application/xml",
+			value:       map[string]string{"name": "Alchemist"},
+			status:      http.StatusNotAcceptable,
+			contentType: "text/plain; charset=utf-8",
+			body:        "not acceptable, available: application/json, application/x-www-form-urlencoded, application/cbor, application/msgpack\n",
+			wantErr:     true,
+		},
+		{
+			name:        "should fall back to the next acceptable codec",
+			accept:      "application/xml, application/json;q=0.5",
+			value:       map[string]string{"name": "Alchemist"},
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        "{\"name\":\"Alchemist\"}\n",
+		},
+		{
+			name:        "should respond 500 before headers when encoding fails",
+			accept:      "application/json",
+			value:       map[string]any{"name": make(chan int)},
+			status:      http.StatusInternalServerError,
+			contentType: "text/plain; charset=utf-8",
+			body:        "Internal Server Error\n",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r := httptest.NewRequest(http.MethodGet, "/books", nil)
+
+			r.Header.Set("Accept", tc.accept)
+
+			err := Negotiate(w, r, tc.status, tc.value)
+
+			assert.Equalf(t, tc.wantErr, err != nil, "want error %v, got %v", tc.wantErr, err)
+
+			assert.Equalf(t, tc.status, w.Code, "want %v, got %v", tc.status, w.Code)
+
+			assert.Equalf(t, tc.contentType, w.Header().Get("Content-Type"), "want %v, got %v", tc.contentType, w.Header().Get("Content-Type"))
+
+			assert.Equalf(t, tc.body, w.Body.String(), "want %v, got %v", tc.body, w.Body.String())
+
+			assert.Equalf(t, "Accept", w.Header().Get("Vary"), "want %v, got %v", "Accept", w.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestNegotiate_NotAcceptableError(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	r := httptest.NewRequest(http.MethodGet, "/books", nil)
+
+	r.Header.Set("Accept", "application/json")
+
+	err := New(codec.NewRegistry(codec.CBOR{})).Negotiate(w, r, http.StatusOK, book{})
+
+	assert.Truef(t, errors.Is(err, ErrNotAcceptable), "want %v, got %v", ErrNotAcceptable, err)
+}
+
+func TestHelpers(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("page").Parse(`<h1>{{.Name}}</h1>`))
+
+	testCases := []struct {
+		name        string
+		render      func(w http.ResponseWriter) error
+		status      int
+		contentType string
+		body        string
+		wantErr     bool
+	}{
+		{
+			name: "json",
+			render: func(w http.ResponseWriter) error {
+				return JSON(w, http.StatusOK, book{Name: "a"})
+			},
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        "{\"name\":\"a\"}\n",
+		},
+		{
+			name: "xml",
+			render: func(w http.ResponseWriter) error {
+				return XML(w, http.StatusAccepted, book{Name: "a"})
+			},
+			status:      http.StatusAccepted,
+			contentType: "application/xml",
+			body:        "<book><name>a</name></book>",
+		},
+		{
+			name: "text",
+			render: func(w http.ResponseWriter) error {
+				return Text(w, http.StatusOK, "OK")
+			},
+			status:      http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        "OK",
+		},
+		{
+			name: "html",
+			render: func(w http.ResponseWriter) error {
+				return HTML(w, http.StatusOK, tmpl, "page", book{Name: "<b>"})
+			},
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			body:        "<h1>&lt;b&gt;</h1>",
+		},
+		{
+			name: "html with missing template",
+			render: func(w http.ResponseWriter) error {
+				return HTML(w, http.StatusOK, tmpl, "missing", nil)
+			},
+			status:      http.StatusInternalServerError,
+			contentType: "text/plain; charset=utf-8",
+			body:        "Internal Server Error\n",
+			wantErr:     true,
+		},
+		{
+			name: "no content",
+			render: func(w http.ResponseWriter) error {
+				return JSON(w, http.StatusNoContent, book{Name: "a"})
+			},
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			err := tc.render(w)
+
+			assert.Equalf(t, tc.wantErr, err != nil, "want error %v, got %v", tc.wantErr, err)
+
+			assert.Equalf(t, tc.status, w.Code, "want %v, got %v", tc.status, w.Code)
+
+			assert.Equalf(t, tc.contentType, w.Header().Get("Content-Type"), "want %v, got %v", tc.contentType, w.Header().Get("Content-Type"))
+
+			assert.Equalf(t, tc.body, w.Body.String(), "want %v, got %v", tc.body, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+func RequestID(config RequestIDConfig) Middleware {
+	cfg := saneRequestIDConfig(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		id := incomingRequestID(cfg, r)
+
+		if id == "" {
+			id = cfg.Generator()
+		}
+
+		w.Header().Set(cfg.Header, id)
+
+		rw, ok := UnwrapResponseWriter(w)
+
+		if ok {
+			rw.RequestID = id
+		}
+
+		next(r.WithContext(WithRequestID(r.Context(), id)))
+	}
+}
+
+func saneRequestIDConfig(in RequestIDConfig) RequestIDConfig {
+	out := RequestIDConfig{
+		Header:    "X-Request-ID",
+		Generator: NewUUIDv7,
+		Validator: validRequestID,
+	}
+
+	if in.Header != "" {
+		out.Header = in.Header
+	}
+
+	if in.Generator != nil {
+		out.Generator = in.Generator
+	}
+
+	if in.Validator != nil {
+		out.Validator = in.Validator
+	}
+
+	return out
+}
+
+func incomingRequestID(cfg RequestIDConfig, r *http.Request) string {
+	id := strings.TrimSpace(r.Header.Get(cfg.Header))
+
+	if id != "" && cfg.Validator(id) {
+		return id
+	}
+
+	traceId, ok := traceIdFromParent(r.Header.Get("traceparent"))
+
+	if ok {
+		return traceId
+	}
+
+	return ""
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIdKey, id)
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIdKey).(string)
+
+	return id, ok && id != ""
+}
+
+func RequestIDFromRequest(r *http.Request) (string, bool) {
+	return RequestIDFromContext(r.Context())
+}
+
+func validRequestID(id string) bool {
+	if len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.ContainsRune("-_.:+/=", c):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func traceIdFromParent(header string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+
+	if len(parts) < 4 {
+		return "", false
+	}
+
+	version, traceId, parentId, flags := parts[0], parts[1], parts[2], parts[3]
+
+	if !isLowerHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return "", false
+	}
+
+	if !isLowerHex(traceId, 32) || !isLowerHex(parentId, 16) || !isLowerHex(flags, 2) {
+		return "", false
+	}
+
+	if strings.Trim(traceId, "0") == "" || strings.Trim(parentId, "0") == "" {
+		return "", false
+	}
+
+	return traceId, true
+}
+
+func isLowerHex(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+
+	for _, c := range value {
+		if !('0' <= c && c <= '9') && !('a' <= c && c <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func NewUUIDv7() string {
+	buffer := uuidv7.next(time.Now())
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buffer[0:4], buffer[4:6], buffer[6:8], buffer[8:10], buffer[10:16])
+}
+
+type uuidv7Generator struct {
+	mu       sync.Mutex
+	lastMs   int64
+	sequence uint16
+}
+
+func (g *uuidv7Generator) next(now time.Time) [16]byte {
+	var buffer [16]byte
+
+	_, _ = rand.Read(buffer[6:])
+
+	g.mu.Lock()
+
+	ms := now.UnixMilli()
+
+	if ms > g.lastMs {
+		g.lastMs, g.sequence = ms, binary.BigEndian.Uint16(buffer[6:8])&0x07ff
+	} else {
+		g.sequence += 1
+
+		if g.sequence > 0x0fff {
+			g.lastMs, g.sequence = g.lastMs+1, 0
+		}
+	}
+
+	ms, sequence := g.lastMs, g.sequence
+
+	g.mu.Unlock()
+
+	binary.BigEndian.PutUint64(buffer[0:8], uint64(ms)<<16|uint64(sequence))
+
+	buffer[6] = 0x70 | buffer[6]&0x0f
+
+	buffer[8] = 0x80 | buffer[8]&0x3f
+
+	return buffer
+}
+
+type RequestIDConfig struct {
+	Header    string
+	Generator func() string
+	Validator func(string) bool
+}
+
+var uuidv7 = &uuidv7Generator{}
+
+const maxRequestIDLength = 128
+
+const requestIdKey = "http_request_id"
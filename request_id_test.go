@@ -0,0 +1,209 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  RequestIDConfig
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:    "should reuse a valid incoming request id",
+			headers: map[string]string{"X-Request-ID": "order-42.retry:1"},
+			want:    "order-42.retry:1",
+		},
+		{
+			name:    "should replace an invalid incoming request id",
+			headers: map[string]string{"X-Request-ID": "<script>alert(1)</script>"},
+			want:    "generated",
+		},
+		{
+			name:    "should replace an oversized incoming request id",
+			headers: map[string]string{"X-Request-ID": strings.Repeat("a", 129)},
+			want:    "generated",
+		},
+		{
+			name:    "should reuse the trace id of a valid traceparent",
+			headers: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			want:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:    "should prefer the request id over the traceparent",
+			headers: map[string]string{"X-Request-ID": "abc", "traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			want:    "abc",
+		},
+		{
+			name:    "should generate an id without incoming headers",
+			headers: map[string]string{},
+			want:    "generated",
+		},
+		{
+			name: "should honour a custom header and validator",
+			config: RequestIDConfig{
+				Header: "X-Correlation-ID",
+				Validator: func(id string) bool {
+					return strings.HasPrefix(id, "corr-")
+				},
+			},
+			headers: map[string]string{"X-Correlation-ID": "corr-7"},
+			want:    "corr-7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := NewMemoryWriter()
+
+			config := tt.config
+
+			config.Generator = func() string {
+				return "generated"
+			}
+
+			header := config.Header
+
+			if header == "" {
+				header = "X-Request-ID"
+			}
+
+			got := ""
+
+			router := NewRouter()
+
+			router.Use(Logger(LoggerConfig{Output: logs}))
+
+			router.Use(RequestID(config))
+
+			router.GetFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+				got, _ = RequestIDFromRequest(r)
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/books/7", nil)
+
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.want, got, "want %v, got %v", tt.want, got)
+
+			assert.Equalf(t, tt.want, w.Header().Get(header), "want %v, got %v", tt.want, w.Header().Get(header))
+
+			assert.Containsf(t, logs.Content, "/books/7 | "+tt.want, "want %v, got %v", tt.want, logs.Content)
+		})
+	}
+}
+
+func Test_traceIdFromParent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "should accept version 00",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantOk: true,
+		},
+		{
+			name:   "should accept future versions with extra fields",
+			header: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future-holds",
+			want:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantOk: true,
+		},
+		{
+			name:   "should reject extra fields on version 00",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		},
+		{
+			name:   "should reject the forbidden version",
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "should reject an all zero trace id",
+			header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "should reject an all zero parent id",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		},
+		{
+			name:   "should reject upper case hex",
+			header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "should reject malformed headers",
+			header: "00-4bf92f35",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := traceIdFromParent(tt.header)
+
+			assert.Equalf(t, tt.wantOk, ok, "want %v, got %v", tt.wantOk, ok)
+
+			assert.Equalf(t, tt.want, got, "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	t.Parallel()
+
+	format := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	ids := make([]string, 0, 1000)
+
+	for i := 0; i < 1000; i += 1 {
+		ids = append(ids, NewUUIDv7())
+	}
+
+	for _, id := range ids {
+		assert.Truef(t, format.MatchString(id), "want a uuid v7, got %v", id)
+	}
+
+	assert.Truef(t, sort.StringsAreSorted(ids), "want sortable ids, got %v", ids)
+}
+
+func Test_uuidv7Generator(t *testing.T) {
+	t.Parallel()
+
+	g := &uuidv7Generator{}
+
+	now := time.UnixMilli(1700000000000)
+
+	first := g.next(now)
+
+	g.sequence = 0x0fff
+
+	second := g.next(now)
+
+	assert.Equalf(t, byte(0x70), second[6]&0xf0, "want version 7, got %x", second[6])
+
+	assert.Equalf(t, byte(0x80), second[8]&0xc0, "want rfc variant, got %x", second[8])
+
+	assert.Truef(t, string(first[:]) < string(second[:]), "want %x before %x after sequence overflow", first, second)
+
+	assert.Equalf(t, int64(1700000000001), g.lastMs, "want the clock to advance, got %v", g.lastMs)
+}
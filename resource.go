@@ -0,0 +1,195 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type Indexer interface {
+	Index(w http.ResponseWriter, r *http.Request) error
+}
+
+type Shower interface {
+	Show(w http.ResponseWriter, r *http.Request) error
+}
+
+type Creator interface {
+	Create(w http.ResponseWriter, r *http.Request) error
+}
+
+type Updater interface {
+	Update(w http.ResponseWriter, r *http.Request) error
+}
+
+type Patcher interface {
+	Patch(w http.ResponseWriter, r *http.Request) error
+}
+
+type Deleter interface {
+	Delete(w http.ResponseWriter, r *http.Request) error
+}
+
+type Registrar interface {
+	HandleMethod(method, pattern string, handler http.Handler, opts ...RouteOption)
+}
+
+type Resource struct {
+	registrar Registrar
+	name      string
+	pattern   string
+	param     string
+	ancestors []string
+	opts      []RouteOption
+}
+
+type ResourceOption func(*Resource)
+
+func WithResourceName(name string) ResourceOption {
+	return func(resource *Resource) {
+		resource.name = name
+	}
+}
+
+func WithResourceParam(param string) ResourceOption {
+	return func(resource *Resource) {
+		resource.param = param
+	}
+}
+
+func WithResourceRoutes(opts ...RouteOption) ResourceOption {
+	return func(resource *Resource) {
+		resource.opts = append(resource.opts, opts...)
+	}
+}
+
+func (router *Router) Resource(pattern string, controller any, opts ...ResourceOption) *Resource {
+	return NewResource(router, pattern, controller, opts...)
+}
+
+func NewResource(registrar Registrar, pattern string, controller any, opts ...ResourceOption) *Resource {
+	pattern = "/" + strings.Trim(pattern, "/")
+
+	resource := &Resource{
+		registrar: registrar,
+		name:      resourceName(pattern),
+		pattern:   pattern,
+		param:     "id",
+		ancestors: make([]string, 0),
+		opts:      make([]RouteOption, 0),
+	}
+
+	return resource.register(controller, opts)
+}
+
+func (resource *Resource) Resource(name string, controller any, opts ...ResourceOption) *Resource {
+	child := &Resource{
+		registrar: resource.registrar,
+		name:      resource.name + "." + resourceName(name),
+		pattern:   resource.MemberPattern() + "/" + strings.Trim(name, "/"),
+		param:     "id",
+		ancestors: append(slices.Clone(resource.ancestors), resource.param),
+		opts:      slices.Clone(resource.opts),
+	}
+
+	return child.register(controller, opts)
+}
+
+func (resource *Resource) register(controller any, opts []ResourceOption) *Resource {
+	for _, opt := range opts {
+		opt(resource)
+	}
+
+	if slices.Contains(resource.ancestors, resource.param) {
+		panic(fmt.Sprintf("resource: param %q of %s is already used by a parent resource, use WithResourceParam", resource.param, resource.pattern))
+	}
+
+	if c, ok := controller.(Indexer); ok {
+		resource.handle(http.MethodGet, resource.pattern, "index", HandlerE(c.Index), nil)
+	}
+
+	if c, ok := controller.(Creator); ok {
+		resource.handle(http.MethodPost, resource.pattern, "create", HandlerE(c.Create), nil)
+	}
+
+	if c, ok := controller.(Shower); ok {
+		resource.handle(http.MethodGet, resource.MemberPattern(), "show", HandlerE(c.Show), nil)
+	}
+
+	if c, ok := controller.(Updater); ok {
+		resource.handle(http.MethodPut, resource.MemberPattern(), "update", HandlerE(c.Update), nil)
+	}
+
+	if c, ok := controller.(Patcher); ok {
+		resource.handle(http.MethodPatch, resource.MemberPattern(), "patch", HandlerE(c.Patch), nil)
+	}
+
+	if c, ok := controller.(Deleter); ok {
+		resource.handle(http.MethodDelete, resource.MemberPattern(), "delete", HandlerE(c.Delete), nil)
+	}
+
+	return resource
+}
+
+func (resource *Resource) Name() string {
+	return resource.name
+}
+
+func (resource *Resource) Pattern() string {
+	return resource.pattern
+}
+
+func (resource *Resource) Param() string {
+	return resource.param
+}
+
+func (resource *Resource) MemberPattern() string {
+	segment := "{" + resource.param + "}"
+
+	router, ok := resource.registrar.(*Router)
+
+	if ok {
+		segment = router.paramSegment(resource.param)
+	}
+
+	return resource.pattern + "/" + segment
+}
+
+func (resource *Resource) Collection(method, action string, handler http.Handler, opts ...RouteOption) {
+	resource.action(method, resource.pattern, action, handler, opts)
+}
+
+func (resource *Resource) Member(method, action string, handler http.Handler, opts ...RouteOption) {
+	resource.action(method, resource.MemberPattern(), action, handler, opts)
+}
+
+func (resource *Resource) action(method, base, action string, handler http.Handler, opts []RouteOption) {
+	action = strings.Trim(action, "/")
+
+	if action == "" {
+		panic(fmt.Sprintf("resource: %s %s action requires a name", method, base))
+	}
+
+	resource.handle(method, base+"/"+action, strings.ReplaceAll(action, "/", "."), handler, opts)
+}
+
+func (resource *Resource) handle(method, pattern, name string, handler http.Handler, opts []RouteOption) {
+	routeOpts := append(slices.Clone(resource.opts), WithName(resource.name+"."+name))
+
+	resource.registrar.HandleMethod(method, pattern, handler, append(routeOpts, opts...)...)
+}
+
+func resourceName(pattern string) string {
+	names := make([]string, 0)
+
+	for _, partial := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if partial == "" || strings.ContainsAny(partial[:1], "{:*") {
+			continue
+		}
+
+		names = append(names, partial)
+	}
+
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"github.com/aakash-rajur/http/params"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+type bookController struct{}
+
+func (bookController) Index(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "index")
+}
+
+func (bookController) Show(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "show")
+}
+
+func (bookController) Create(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "create")
+}
+
+func (bookController) Update(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "update")
+}
+
+func (bookController) Patch(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "patch")
+}
+
+func (bookController) Delete(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "delete")
+}
+
+type reviewController struct{}
+
+func (reviewController) Index(w http.ResponseWriter, r *http.Request) error {
+	return writeAction(w, r, "reviews")
+}
+
+func writeAction(w http.ResponseWriter, r *http.Request, action string) error {
+	p, _ := params.FromRequest(r)
+
+	_, err := w.Write([]byte(action + " " + p.Get("userId", "-") + " " + p.Get("bookId", p.Get("id", "-"))))
+
+	return err
+}
+
+func TestRouter_Resource(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	books := router.Resource("/users/{userId}/books", bookController{}, WithResourceParam("bookId"))
+
+	books.Collection(http.MethodGet, "search", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = writeAction(w, r, "search")
+	}))
+
+	books.Member(http.MethodPost, "publish", HandlerE(func(w http.ResponseWriter, r *http.Request) error {
+		return writeAction(w, r, "publish")
+	}))
+
+	books.Resource("reviews", reviewController{})
+
+	tests := []struct {
+		method   string
+		target   string
+		wantBody string
+	}{
+		{method: http.MethodGet, target: "/users/1/books", wantBody: "index 1 -"},
+		{method: http.MethodPost, target: "/users/1/books", wantBody: "create 1 -"},
+		{method: http.MethodGet, target: "/users/1/books/2", wantBody: "show 1 2"},
+		{method: http.MethodPut, target: "/users/1/books/2", wantBody: "update 1 2"},
+		{method: http.MethodPatch, target: "/users/1/books/2", wantBody: "patch 1 2"},
+		{method: http.MethodDelete, target: "/users/1/books/2", wantBody: "delete 1 2"},
+		{method: http.MethodGet, target: "/users/1/books/search", wantBody: "search 1 -"},
+		{method: http.MethodPost, target: "/users/1/books/2/publish", wantBody: "publish 1 2"},
+		{method: http.MethodGet, target: "/users/1/books/2/reviews", wantBody: "reviews 1 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.Equalf(t, http.StatusOK, w.Code, "want %v, got %v", http.StatusOK, w.Code)
+
+			assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+		})
+	}
+
+	names := make([]string, 0)
+
+	for _, route := range router.Routes() {
+		names = append(names, route.Name)
+	}
+
+	sort.Strings(names)
+
+	expected := []string{
+		"users.books.create",
+		"users.books.delete",
+		"users.books.index",
+		"users.books.patch",
+		"users.books.publish",
+		"users.books.reviews.index",
+		"users.books.search",
+		"users.books.show",
+		"users.books.update",
+	}
+
+	assert.Equalf(t, expected, names, "want %v, got %v", expected, names)
+}
+
+func TestRouter_Resource_Options(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Dialect(DialectColon)
+
+	resource := router.Resource(
+		"/api/books/",
+		struct{ reviewController }{},
+		WithResourceName("library"),
+		WithResourceRoutes(WithMatchers(MatchHeader("X-Tenant", "acme"))),
+	)
+
+	assert.Equalf(t, "/api/books/:id", resource.MemberPattern(), "want colon member pattern, got %v", resource.MemberPattern())
+
+	route, ok := router.NamedRoute("library.index")
+
+	assert.Truef(t, ok, "want library.index to be registered")
+
+	assert.Lenf(t, route.Matchers, 1, "want route options applied, got %v", route.Matchers)
+
+	assert.Panicsf(t, func() {
+		resource.Resource("chapters", reviewController{})
+	}, "want nested resource with a clashing param to panic")
+
+	assert.Panicsf(t, func() {
+		resource.Member(http.MethodPost, "", http.NotFoundHandler())
+	}, "want unnamed action to panic")
+}
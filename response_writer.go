@@ -10,6 +10,10 @@ type ResponseWriter struct {
 	http.ResponseWriter
 
 	StatusCode int
+
+	Err error
+
+	RequestID string
 }
 
 func (rw *ResponseWriter) Hijacker() (http.Hijacker, bool) {
@@ -32,8 +36,38 @@ func (rw *ResponseWriter) Flush() {
 	rw.ResponseWriter.(http.Flusher).Flush()
 }
 
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+func (rw *ResponseWriter) Write(buffer []byte) (int, error) {
+	if rw.StatusCode == 0 {
+		rw.StatusCode = http.StatusOK
+	}
+
+	return rw.ResponseWriter.Write(buffer)
+}
+
 func (rw *ResponseWriter) WriteHeader(statusCode int) {
 	rw.StatusCode = statusCode
 
 	rw.ResponseWriter.WriteHeader(statusCode)
 }
+
+func UnwrapResponseWriter(w http.ResponseWriter) (*ResponseWriter, bool) {
+	for {
+		rw, ok := w.(*ResponseWriter)
+
+		if ok {
+			return rw, true
+		}
+
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+
+		if !ok {
+			return nil, false
+		}
+
+		w = unwrapper.Unwrap()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -178,3 +179,49 @@ func (c *hijackerConn) SetReadDeadline(t time.Time) error {
 func (c *hijackerConn) SetWriteDeadline(t time.Time) error {
 	return nil
 }
+
+func TestResponseWriter_ImplicitStatus(t *testing.T) {
+	t.Parallel()
+
+	rw := &ResponseWriter{ResponseWriter: httptest.NewRecorder()}
+
+	_, _ = rw.Write([]byte("body"))
+
+	assert.Equal(t, http.StatusOK, rw.StatusCode, "want implicit 200 after write")
+}
+
+type wrappedWriter struct {
+	http.ResponseWriter
+}
+
+func (w wrappedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestUnwrapResponseWriter(t *testing.T) {
+	t.Parallel()
+
+	rw := &ResponseWriter{ResponseWriter: httptest.NewRecorder()}
+
+	testCases := []struct {
+		name   string
+		writer http.ResponseWriter
+		ok     bool
+	}{
+		{name: "direct", writer: rw, ok: true},
+		{name: "wrapped", writer: wrappedWriter{wrappedWriter{rw}}, ok: true},
+		{name: "foreign", writer: httptest.NewRecorder(), ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := UnwrapResponseWriter(tc.writer)
+
+			assert.Equal(t, tc.ok, ok, "want ok")
+
+			if tc.ok {
+				assert.Same(t, rw, actual, "want the router response writer")
+			}
+		})
+	}
+}
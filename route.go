@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+	"reflect"
+)
+
+type Route struct {
+	Name     string
+	Method   string
+	Host     string
+	Pattern  string
+	Handler  http.Handler
+	Matchers []Matcher
+	Request  reflect.Type
+	Response reflect.Type
+	Metadata map[string]any
+}
+
+type RouteOption func(*Route)
+
+func WithMatchers(matchers ...Matcher) RouteOption {
+	return func(route *Route) {
+		route.Matchers = append(route.Matchers, matchers...)
+	}
+}
+
+func WithName(name string) RouteOption {
+	return func(route *Route) {
+		route.Name = name
+	}
+}
+
+func WithMetadata(key string, value any) RouteOption {
+	return func(route *Route) {
+		if route.Metadata == nil {
+			route.Metadata = make(map[string]any)
+		}
+
+		route.Metadata[key] = value
+	}
+}
+
+func WithHost(host string) RouteOption {
+	return func(route *Route) {
+		route.Host = host
+
+		route.Matchers = append(route.Matchers, MatchHost(host))
+	}
+}
+
+func newRoute(method, pattern string, handler http.Handler, opts []RouteOption) *Route {
+	route := &Route{
+		Method:   method,
+		Pattern:  pattern,
+		Handler:  handler,
+		Matchers: make([]Matcher, 0),
+	}
+
+	typed, ok := handler.(TypedHandler)
+
+	if ok {
+		route.Request, route.Response = typed.RequestType(), typed.ResponseType()
+	}
+
+	for _, opt := range opts {
+		opt(route)
+	}
+
+	return route
+}
+
+func (route *Route) String() string {
+	pattern := route.Host + route.Pattern
+
+	if route.Method == "" {
+		return pattern
+	}
+
+	return route.Method + " " + pattern
+}
+
+func (route *Route) Value(key string) (any, bool) {
+	value, ok := route.Metadata[key]
+
+	return value, ok
+}
+
+func routeValue[T any](r *http.Request, key string) (T, bool) {
+	var zero T
+
+	route, ok := RouteFromRequest(r)
+
+	if !ok {
+		return zero, false
+	}
+
+	value, ok := route.Value(key)
+
+	if !ok {
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+func (route *Route) match(r *http.Request) error {
+	for _, matcher := range route.Matchers {
+		err := matcher(r)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type routeContext struct {
+	router  *Router
+	route   *Route
+	pending bool
+	resolve sync.Once
+}
+
+func withRouter(ctx context.Context, router *Router) context.Context {
+	return context.WithValue(ctx, routeContextKey, &routeContext{router: router, pending: true})
+}
+
+func withRouteContext(ctx context.Context, router *Router, route *Route) context.Context {
+	return context.WithValue(ctx, routeContextKey, &routeContext{router: router, route: route})
+}
+
+func RouteFromRequest(r *http.Request) (*Route, bool) {
+	rc, ok := r.Context().Value(routeContextKey).(*routeContext)
+
+	if !ok {
+		return nil, false
+	}
+
+	if rc.pending {
+		rc.resolve.Do(func() {
+			rc.route = rc.router.lookup(r)
+		})
+	}
+
+	return rc.route, rc.route != nil
+}
+
+func routerFromRequest(r *http.Request) (*Router, bool) {
+	rc, ok := r.Context().Value(routeContextKey).(*routeContext)
+
+	if !ok || rc.router == nil {
+		return nil, false
+	}
+
+	return rc.router, true
+}
+
+const routeContextKey = "http_route_context"
@@ -2,18 +2,32 @@ package http
 
 import (
 	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/problem"
 	"github.com/aakash-rajur/http/register"
+	"maps"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 func NewRouter() *Router {
 	mux := &Router{
 		middlewares: make(Middlewares, 0),
 		register:    register.NewRegister(),
-		notFound:    http.NotFoundHandler(),
 	}
 
+	mux.notFound = http.HandlerFunc(mux.fallback)
+
+	mux.state.Store(&routerState{
+		names:   make(map[string]*Route),
+		dialect: DialectBoth,
+		errors:  problem.Render,
+	})
+
 	return mux
 }
 
@@ -23,28 +37,76 @@ type Router struct {
 	next        http.HandlerFunc
 	register    register.Register
 	notFound    http.Handler
+	state       atomic.Pointer[routerState]
 }
 
-func (router *Router) HandleMethod(method, pattern string, handler http.Handler) {
+type routerState struct {
+	names        map[string]*Route
+	dialect      Dialect
+	errors       ErrorRenderer
+	errorHandler ErrorHandler
+}
+
+func (router *Router) HandleMethod(method, pattern string, handler http.Handler, opts ...RouteOption) {
+	if strings.Contains(pattern, "{$}") && pattern != "/{$}" {
+		panic(fmt.Sprintf("http: %s anchors a path other than the root, net/http.ServeMux matches it only with a trailing slash", pattern))
+	}
+
 	router.mu.Lock()
 
 	defer router.mu.Unlock()
 
-	path := pathWithMethod(method, pattern)
+	state := *router.state.Load()
+
+	path := pathWithMethod(method, register.Normalize(pattern, state.dialect))
+
+	route := newRoute(method, pattern, handler, opts)
+
+	if route.Name != "" {
+		existing, ok := state.names[route.Name]
 
-	router.register = router.register.Add(path, handler)
+		if ok {
+			panic(fmt.Sprintf("http: route name %q of %s is already used by %s", route.Name, route, existing))
+		}
+
+		state.names = maps.Clone(state.names)
+
+		state.names[route.Name] = route
+
+		router.state.Store(&state)
+	}
+
+	router.register = router.register.AddValue(path, handler, route)
 }
 
-func (router *Router) HandleMethodFunc(method, pattern string, handlerFunc http.HandlerFunc) {
-	router.HandleMethod(method, pattern, handlerFunc)
+func (router *Router) HandleMethodE(method, pattern string, handler HandlerE, opts ...RouteOption) {
+	router.HandleMethod(method, pattern, handler, opts...)
 }
 
-func (router *Router) Handle(pattern string, handler http.Handler) {
-	router.HandleMethod(":http_method", pattern, handler)
+func (router *Router) HandleMethodFunc(method, pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.HandleMethod(method, pattern, handlerFunc, opts...)
 }
 
-func (router *Router) HandleFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Handle(pattern, handlerFunc)
+func (router *Router) Handle(pattern string, handler http.Handler, opts ...RouteOption) {
+	method, host, path := parsePattern(pattern)
+
+	if path != "/" && strings.HasSuffix(path, "/") {
+		panic(fmt.Sprintf("http: %s matches a subtree in net/http.ServeMux, use %s{path...} instead", pattern, path))
+	}
+
+	if host != "" {
+		opts = append([]RouteOption{WithHost(host)}, opts...)
+	}
+
+	router.HandleMethod(method, path, handler, opts...)
+}
+
+func (router *Router) HandleFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Handle(pattern, handlerFunc, opts...)
+}
+
+func (router *Router) HandleE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Handle(pattern, handler, opts...)
 }
 
 func (router *Router) NotFound(handler http.Handler) {
@@ -55,6 +117,42 @@ func (router *Router) NotFound(handler http.Handler) {
 	router.notFound = handler
 }
 
+func (router *Router) ErrorHandler(handler ErrorHandler) {
+	router.mu.Lock()
+
+	defer router.mu.Unlock()
+
+	state := *router.state.Load()
+
+	state.errorHandler = handler
+
+	router.state.Store(&state)
+}
+
+func (router *Router) ErrorRenderer(renderer ErrorRenderer) {
+	router.mu.Lock()
+
+	defer router.mu.Unlock()
+
+	state := *router.state.Load()
+
+	state.errors = renderer
+
+	router.state.Store(&state)
+}
+
+func (router *Router) Dialect(dialect Dialect) {
+	router.mu.Lock()
+
+	defer router.mu.Unlock()
+
+	state := *router.state.Load()
+
+	state.dialect = dialect
+
+	router.state.Store(&state)
+}
+
 func (router *Router) Use(middleware Middleware) {
 	router.middlewares = router.middlewares.Append(middleware)
 
@@ -68,7 +166,7 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		router.next = router.serve
 	}
 
-	router.next(hw, r)
+	router.next(hw, r.WithContext(withRouter(r.Context(), router)))
 }
 
 func (router *Router) serve(w http.ResponseWriter, r *http.Request) {
@@ -86,23 +184,202 @@ func (router *Router) serve(w http.ResponseWriter, r *http.Request) {
 
 	defer router.mu.RUnlock()
 
-	path := pathWithMethod(r.Method, r.URL.Path)
-
-	entry, params, err := router.register.Find(path)
+	match, status, err := router.find(r, r.Method)
 
-	if err == nil {
-		pr := r.WithContext(params.WithinContext(r.Context()))
+	if status == http.StatusNotFound && r.Method == http.MethodHead {
+		match, status, err = router.find(r, http.MethodGet)
+	}
 
-		entry.Handler.ServeHTTP(w, pr)
+	if err != nil {
+		router.fail(w, r, problem.New(http.StatusInternalServerError, ""))
 
 		return
 	}
 
-	if !errors.Is(err, register.ErrNotFound) {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if status == http.StatusNotFound {
+		allowed := router.allowedMethods(r)
 
-		return
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+
+			status = http.StatusMethodNotAllowed
+		}
+	}
+
+	switch status {
+	case http.StatusOK:
+		route, _ := match.Value.(*Route)
+
+		ctx := withRouteContext(match.Params.WithinContext(r.Context()), router, route)
+
+		pr := r.WithContext(ctx)
+
+		if route != nil {
+			withPattern(pr, route.String(), match.Params)
+		}
+
+		match.Handler.ServeHTTP(w, pr)
+	case http.StatusNotFound:
+		router.notFound.ServeHTTP(w, r.WithContext(withRouteContext(r.Context(), router, nil)))
+	default:
+		router.fail(w, r, problem.New(status, ""))
+	}
+}
+
+func (router *Router) Routes() []*Route {
+	router.mu.RLock()
+
+	defer router.mu.RUnlock()
+
+	routes := make([]*Route, 0, len(router.register))
+
+	for _, entry := range router.register {
+		route, ok := entry.Value.(*Route)
+
+		if ok {
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
+func (router *Router) lookup(r *http.Request) *Route {
+	return router.lookupMethod(r, r.Method)
+}
+
+func (router *Router) lookupMethod(r *http.Request, method string) *Route {
+	router.mu.RLock()
+
+	defer router.mu.RUnlock()
+
+	match, status, _ := router.find(r, method)
+
+	if status == http.StatusNotFound && method == http.MethodHead {
+		match, status, _ = router.find(r, http.MethodGet)
+	}
+
+	if status != http.StatusOK {
+		return nil
+	}
+
+	route, _ := match.Value.(*Route)
+
+	return route
+}
+
+func (router *Router) AllowedMethods(r *http.Request) []string {
+	router.mu.RLock()
+
+	defer router.mu.RUnlock()
+
+	return router.allowedMethods(r)
+}
+
+func (router *Router) allowedMethods(r *http.Request) []string {
+	seen := make(map[string]bool)
+
+	allowed := make([]string, 0)
+
+	for _, entry := range router.register {
+		route, ok := entry.Value.(*Route)
+
+		if !ok || route.Method == "" || seen[route.Method] {
+			continue
+		}
+
+		seen[route.Method] = true
+
+		_, status, _ := router.find(r, route.Method)
+
+		if status == http.StatusOK {
+			allowed = append(allowed, route.Method)
+		}
+	}
+
+	if slices.Contains(allowed, http.MethodGet) && !slices.Contains(allowed, http.MethodHead) {
+		allowed = append(allowed, http.MethodHead)
+	}
+
+	sort.Strings(allowed)
+
+	return allowed
+}
+
+func (router *Router) fallback(w http.ResponseWriter, r *http.Request) {
+	router.fail(w, r, problem.New(http.StatusNotFound, ""))
+}
+
+func (router *Router) fail(w http.ResponseWriter, r *http.Request, details *problem.Details) {
+	if details.Instance == "" {
+		details.Instance = r.URL.Path
+	}
+
+	router.state.Load().errors(w, r, details)
+}
+
+func (router *Router) find(r *http.Request, method string) (register.Match, int, error) {
+	path := pathWithMethod(method, r.URL.Path)
+
+	matches, err := router.register.FindAll(path)
+
+	if errors.Is(err, register.ErrNotFound) {
+		return register.Match{}, http.StatusNotFound, nil
+	}
+
+	if err != nil {
+		return register.Match{}, http.StatusInternalServerError, err
+	}
+
+	match, status := selectMatch(matches, r)
+
+	return match, status, nil
+}
+
+func selectMatch(matches []register.Match, r *http.Request) (register.Match, int) {
+	status := http.StatusNotFound
+
+	for left := 0; left < len(matches); {
+		pattern := matches[left].Pattern()
+
+		right := left + 1
+
+		for right < len(matches) && matches[right].Pattern() == pattern {
+			right += 1
+		}
+
+		chosen, chosenMatchers := register.Match{}, -1
+
+		for _, match := range matches[left:right] {
+			route, ok := match.Value.(*Route)
+
+			if !ok {
+				route = &Route{}
+			}
+
+			err := route.match(r)
+
+			if err != nil {
+				status = max(status, matchStatus(err))
+
+				continue
+			}
+
+			if len(route.Matchers) > chosenMatchers {
+				chosen, chosenMatchers = match, len(route.Matchers)
+			}
+		}
+
+		if chosenMatchers > -1 {
+			if route, ok := chosen.Value.(*Route); ok && route.Method == "" {
+				delete(chosen.Params, methodParam)
+			}
+
+			return chosen, http.StatusOK
+		}
+
+		left = right
 	}
 
-	router.notFound.ServeHTTP(w, r)
+	return register.Match{}, status
 }
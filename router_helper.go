@@ -2,74 +2,110 @@ package http
 
 import "net/http"
 
-func (router *Router) Get(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodGet, pattern, handler)
+func (router *Router) Get(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodGet, pattern, handler, opts...)
 }
 
-func (router *Router) GetFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Get(pattern, handlerFunc)
+func (router *Router) GetFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Get(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Post(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodPost, pattern, handler)
+func (router *Router) Post(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodPost, pattern, handler, opts...)
 }
 
-func (router *Router) PostFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Post(pattern, handlerFunc)
+func (router *Router) PostFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Post(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Put(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodPut, pattern, handler)
+func (router *Router) Put(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodPut, pattern, handler, opts...)
 }
 
-func (router *Router) PutFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Put(pattern, handlerFunc)
+func (router *Router) PutFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Put(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Patch(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodPatch, pattern, handler)
+func (router *Router) Patch(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodPatch, pattern, handler, opts...)
 }
 
-func (router *Router) PatchFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Patch(pattern, handlerFunc)
+func (router *Router) PatchFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Patch(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Delete(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodDelete, pattern, handler)
+func (router *Router) Delete(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodDelete, pattern, handler, opts...)
 }
 
-func (router *Router) DeleteFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Delete(pattern, handlerFunc)
+func (router *Router) DeleteFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Delete(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Head(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodHead, pattern, handler)
+func (router *Router) Head(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodHead, pattern, handler, opts...)
 }
 
-func (router *Router) HeadFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Head(pattern, handlerFunc)
+func (router *Router) HeadFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Head(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Options(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodOptions, pattern, handler)
+func (router *Router) Options(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodOptions, pattern, handler, opts...)
 }
 
-func (router *Router) OptionsFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Options(pattern, handlerFunc)
+func (router *Router) OptionsFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Options(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Trace(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodTrace, pattern, handler)
+func (router *Router) Trace(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodTrace, pattern, handler, opts...)
 }
 
-func (router *Router) TraceFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Trace(pattern, handlerFunc)
+func (router *Router) TraceFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Trace(pattern, handlerFunc, opts...)
 }
 
-func (router *Router) Connect(pattern string, handler http.Handler) {
-	router.HandleMethod(http.MethodConnect, pattern, handler)
+func (router *Router) Connect(pattern string, handler http.Handler, opts ...RouteOption) {
+	router.HandleMethod(http.MethodConnect, pattern, handler, opts...)
 }
 
-func (router *Router) ConnectFunc(pattern string, handlerFunc http.HandlerFunc) {
-	router.Get(pattern, handlerFunc)
+func (router *Router) ConnectFunc(pattern string, handlerFunc http.HandlerFunc, opts ...RouteOption) {
+	router.Connect(pattern, handlerFunc, opts...)
+}
+
+func (router *Router) GetE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Get(pattern, handler, opts...)
+}
+
+func (router *Router) PostE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Post(pattern, handler, opts...)
+}
+
+func (router *Router) PutE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Put(pattern, handler, opts...)
+}
+
+func (router *Router) PatchE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Patch(pattern, handler, opts...)
+}
+
+func (router *Router) DeleteE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Delete(pattern, handler, opts...)
+}
+
+func (router *Router) HeadE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Head(pattern, handler, opts...)
+}
+
+func (router *Router) OptionsE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Options(pattern, handler, opts...)
+}
+
+func (router *Router) TraceE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Trace(pattern, handler, opts...)
+}
+
+func (router *Router) ConnectE(pattern string, handler HandlerE, opts ...RouteOption) {
+	router.Connect(pattern, handler, opts...)
 }
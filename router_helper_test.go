@@ -275,4 +275,54 @@ func TestRouter_ConnectFunc(t *testing.T) {
 		fmt.Sprintf("%v", router.register[0].Handler),
 		"expected handler to be registered",
 	)
+
+	assert.Equalf(
+		t,
+		http.MethodConnect,
+		router.register[0].Value.(*Route).Method,
+		"want %v, got %v",
+		http.MethodConnect,
+		router.register[0].Value.(*Route).Method,
+	)
+}
+
+func TestRouter_MethodE(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method   string
+		register func(router *Router, handler HandlerE)
+	}{
+		{method: http.MethodGet, register: func(router *Router, handler HandlerE) { router.GetE("/test", handler) }},
+		{method: http.MethodPost, register: func(router *Router, handler HandlerE) { router.PostE("/test", handler) }},
+		{method: http.MethodPut, register: func(router *Router, handler HandlerE) { router.PutE("/test", handler) }},
+		{method: http.MethodPatch, register: func(router *Router, handler HandlerE) { router.PatchE("/test", handler) }},
+		{method: http.MethodDelete, register: func(router *Router, handler HandlerE) { router.DeleteE("/test", handler) }},
+		{method: http.MethodHead, register: func(router *Router, handler HandlerE) { router.HeadE("/test", handler) }},
+		{method: http.MethodOptions, register: func(router *Router, handler HandlerE) { router.OptionsE("/test", handler) }},
+		{method: http.MethodTrace, register: func(router *Router, handler HandlerE) { router.TraceE("/test", handler) }},
+		{method: http.MethodConnect, register: func(router *Router, handler HandlerE) { router.ConnectE("/test", handler) }},
+		{method: http.MethodPost, register: func(router *Router, handler HandlerE) { router.HandleMethodE(http.MethodPost, "/test", handler) }},
+		{method: http.MethodPut, register: func(router *Router, handler HandlerE) { router.HandleE("PUT /test", handler) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			router := NewRouter()
+
+			tt.register(router, func(w http.ResponseWriter, r *http.Request) error {
+				return Errorf(http.StatusTeapot, "")
+			})
+
+			route, ok := router.register[0].Value.(*Route)
+
+			assert.Truef(t, ok, "want route value, got %v", router.register[0].Value)
+
+			assert.Equalf(t, tt.method, route.Method, "want %v, got %v", tt.method, route.Method)
+
+			_, ok = router.register[0].Handler.(HandlerE)
+
+			assert.Truef(t, ok, "want HandlerE, got %T", router.register[0].Handler)
+		})
+	}
 }
@@ -377,6 +377,37 @@ func TestRouter_HandleFunc(t *testing.T) {
 	}
 }
 
+func TestRouter_Handle_ServeMuxDivergence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern   string
+		wantPanic bool
+	}{
+		{pattern: "/", wantPanic: false},
+		{pattern: "/{$}", wantPanic: false},
+		{pattern: "GET /static/{path...}", wantPanic: false},
+		{pattern: "/static/", wantPanic: true},
+		{pattern: "GET example.com/static/", wantPanic: true},
+		{pattern: "GET /books/{$}", wantPanic: true},
+		{pattern: "/books/{$}/reviews", wantPanic: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			register := func() {
+				NewRouter().HandleFunc(tt.pattern, func(w http.ResponseWriter, r *http.Request) {})
+			}
+
+			if tt.wantPanic {
+				assert.Panicsf(t, register, "want %s to panic", tt.pattern)
+			} else {
+				assert.NotPanicsf(t, register, "want %s to register", tt.pattern)
+			}
+		})
+	}
+}
+
 func TestRouter_NotFound(t *testing.T) {
 	router := NewRouter()
 
@@ -840,14 +871,16 @@ func TestRouter_ServeHTTP_ParseApi(t *testing.T) {
 		},
 	}
 
-	for _, tc := range tests {
-		tc := tc
+	for _, variant := range dialectVariants {
+		router := setupRouter(tests, variant)
 
-		router := setupRouter(tests)
+		for _, tc := range tests {
+			tc := tc
 
-		seed := fmt.Sprintf("parse:%d", time.Now().UnixNano())
+			seed := fmt.Sprintf("parse:%d", time.Now().UnixNano())
 
-		t.Run(tc.Name, validate(tc, seed, router))
+			t.Run(variant.name+"/"+tc.Name, validate(tc, seed, variant.dialect, router))
+		}
 	}
 }
 
@@ -2094,20 +2127,105 @@ func TestRouter_ServeHTTP_GithubApi(t *testing.T) {
 		},
 	}
 
-	for _, tc := range testCases {
-		tc := tc
+	for _, variant := range dialectVariants {
+		router := setupRouter(testCases, variant)
 
-		router := setupRouter(testCases)
+		for _, tc := range testCases {
+			tc := tc
 
-		seed := fmt.Sprintf("github:%d", time.Now().UnixNano())
+			seed := fmt.Sprintf("github:%d", time.Now().UnixNano())
 
-		t.Run(tc.Name, validate(tc, seed, router))
+			t.Run(variant.name+"/"+tc.Name, validate(tc, seed, variant.dialect, router))
+		}
 	}
 }
 
-func setupRouter(testRoutes []TestRoute) http.Handler {
+func TestRouter_Dialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		dialect    Dialect
+		pattern    string
+		target     string
+		wantStatus int
+		wantParams string
+	}{
+		{
+			name:       "should match colon params by default",
+			dialect:    DialectBoth,
+			pattern:    "/api/v2/books/:id",
+			target:     "/api/v2/books/10",
+			wantStatus: http.StatusOK,
+			wantParams: `{"id":"10"}`,
+		},
+		{
+			name:       "should match catch-all",
+			dialect:    DialectColon,
+			pattern:    "/static/*filepath",
+			target:     "/static/css/site.css",
+			wantStatus: http.StatusOK,
+			wantParams: `{"filepath":"css/site.css"}`,
+		},
+		{
+			name:       "should treat colon as literal with braces dialect",
+			dialect:    DialectBraces,
+			pattern:    "/api/v2/books/:id",
+			target:     "/api/v2/books/10",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+
+			router.Dialect(tt.dialect)
+
+			router.GetFunc(
+				tt.pattern,
+				func(w http.ResponseWriter, r *http.Request) {
+					p, _ := params.FromRequest(r)
+
+					_ = json.NewEncoder(w).Encode(p)
+				},
+			)
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "code should be %d", tt.wantStatus)
+
+			if tt.wantParams == "" {
+				return
+			}
+
+			assert.JSONEqf(t, tt.wantParams, w.Body.String(), "params should be %s", tt.wantParams)
+		})
+	}
+}
+
+type dialectVariant struct {
+	name      string
+	dialect   Dialect
+	alternate bool
+}
+
+var dialectVariants = []dialectVariant{
+	{name: "braces", dialect: DialectBraces},
+	{name: "colon", dialect: DialectColon, alternate: true},
+	{name: "both", dialect: DialectBoth},
+	{name: "both-colon", dialect: DialectBoth, alternate: true},
+}
+
+func setupRouter(testRoutes []TestRoute, variant dialectVariant) http.Handler {
 	router := NewRouter()
 
+	router.Dialect(variant.dialect)
+
 	m1 := func(w http.ResponseWriter, r *http.Request, next Next) {
 		ctx := context.WithValue(r.Context(), "m1", "m1")
 
@@ -2175,15 +2293,21 @@ func setupRouter(testRoutes []TestRoute) http.Handler {
 	}
 
 	for _, tc := range testRoutes {
-		router.HandleMethodFunc(tc.Method, tc.Pattern, handler)
+		pattern := tc.Pattern
+
+		if variant.alternate {
+			pattern = tc.alternatePattern()
+		}
+
+		router.HandleMethodFunc(tc.Method, pattern, handler)
 	}
 
 	return router
 }
 
-func validate(tc TestRoute, seed string, router http.Handler) func(t *testing.T) {
+func validate(tc TestRoute, seed string, dialect Dialect, router http.Handler) func(t *testing.T) {
 	return func(t *testing.T) {
-		tcp := tc.generateParams(seed)
+		tcp := tc.generateParams(seed, dialect)
 
 		want := map[string]any{
 			"m1":     "m1",
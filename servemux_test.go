@@ -0,0 +1,104 @@
+//go:build go1.23
+
+//go:debug httpmuxgo121=0
+
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter_ServeMuxCompatibility(t *testing.T) {
+	t.Parallel()
+
+	patterns := []string{
+		"/{$}",
+		"GET /books",
+		"POST /books",
+		"GET /books/new",
+		"GET /books/{id}",
+		"DELETE /books/{id}",
+		"/books/{id}/reviews",
+		"GET /static/{path...}",
+		"GET /static/css/{file}",
+		"GET /users/{id}/books",
+		"GET /users/me/books",
+		"GET api.example.com/status",
+	}
+
+	respond := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Pattern", r.Pattern)
+
+		w.Header().Set("X-Id", r.PathValue("id"))
+
+		w.Header().Set("X-Path", r.PathValue("path"))
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	mux := http.NewServeMux()
+
+	router := NewRouter()
+
+	for _, pattern := range patterns {
+		mux.HandleFunc(pattern, respond)
+
+		router.HandleFunc(pattern, respond)
+	}
+
+	tests := []struct {
+		method string
+		target string
+	}{
+		{method: http.MethodGet, target: "/"},
+		{method: http.MethodPost, target: "/"},
+		{method: http.MethodGet, target: "/books"},
+		{method: http.MethodPost, target: "/books"},
+		{method: http.MethodHead, target: "/books"},
+		{method: http.MethodPut, target: "/books"},
+		{method: http.MethodGet, target: "/books/new"},
+		{method: http.MethodGet, target: "/books/10"},
+		{method: http.MethodDelete, target: "/books/10"},
+		{method: http.MethodDelete, target: "/books/new"},
+		{method: http.MethodGet, target: "/books/10/reviews"},
+		{method: http.MethodPatch, target: "/books/10/reviews"},
+		{method: http.MethodGet, target: "/books/new/reviews"},
+		{method: http.MethodGet, target: "/static/js/vendor/app.js"},
+		{method: http.MethodGet, target: "/static/css/site.css"},
+		{method: http.MethodGet, target: "/static/css/vendor/site.css"},
+		{method: http.MethodGet, target: "/users/me/books"},
+		{method: http.MethodGet, target: "/users/10/books"},
+		{method: http.MethodGet, target: "http://api.example.com/status"},
+		{method: http.MethodGet, target: "http://www.example.com/status"},
+		{method: http.MethodGet, target: "/missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			want := httptest.NewRecorder()
+
+			mux.ServeHTTP(want, httptest.NewRequest(tt.method, tt.target, nil))
+
+			got := httptest.NewRecorder()
+
+			router.ServeHTTP(got, httptest.NewRequest(tt.method, tt.target, nil))
+
+			wantOK, gotOK := want.Code == http.StatusOK, got.Code == http.StatusOK
+
+			assert.Equalf(t, wantOK, gotOK, "served = %v, want %v (%d)", gotOK, wantOK, want.Code)
+
+			for _, header := range []string{"X-Pattern", "X-Id", "X-Path"} {
+				assert.Equalf(
+					t,
+					want.Header().Get(header),
+					got.Header().Get(header),
+					"%s should match http.ServeMux",
+					header,
+				)
+			}
+		})
+	}
+}
@@ -23,8 +23,14 @@ type TestRoute struct {
 	Pattern string `json:"Pattern" yaml:"Pattern"`
 }
 
-func (tr *TestRoute) generatePath(seed string) (string, map[string]any) {
-	re, err := regexp.Compile("{([^}]+)}")
+func (tr *TestRoute) generatePath(seed string, dialect Dialect) (string, map[string]any) {
+	expr := "{([^}]+)}"
+
+	if dialect&DialectColon != 0 {
+		expr = "{([^}]+)}|\\*([^/]+)$"
+	}
+
+	re, err := regexp.Compile(expr)
 
 	if err != nil {
 		return "", nil
@@ -39,6 +45,10 @@ func (tr *TestRoute) generatePath(seed string) (string, map[string]any) {
 	for _, match := range matches {
 		paramMatch, paramName := match[0], match[1]
 
+		if paramName == "" {
+			paramName = match[2]
+		}
+
 		paramSeed := paramName
 
 		if seed != "" {
@@ -65,8 +75,8 @@ func (tr *TestRoute) generateBody(seed string) Body {
 	return payload
 }
 
-func (tr *TestRoute) generateParams(seed string) TestRouteParams {
-	path, params := tr.generatePath(seed)
+func (tr *TestRoute) generateParams(seed string, dialect Dialect) TestRouteParams {
+	path, params := tr.generatePath(seed, dialect)
 
 	return TestRouteParams{
 		Method:  tr.Method,
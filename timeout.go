@@ -0,0 +1,292 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/problem"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+func Timeout(config TimeoutConfig) Middleware {
+	cfg := saneTimeoutConfig(config)
+
+	return func(w http.ResponseWriter, r *http.Request, next Next) {
+		duration := cfg.Duration
+
+		override, ok := routeValue[time.Duration](r, timeoutKey)
+
+		if ok {
+			duration = override
+		}
+
+		if duration <= 0 {
+			next(r)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), duration)
+
+		defer cancel()
+
+		rw, ok := UnwrapResponseWriter(w)
+
+		if !ok {
+			next(r.WithContext(ctx))
+
+			return
+		}
+
+		original := rw.ResponseWriter
+
+		tw := &timeoutWriter{
+			ResponseWriter: original,
+			header:         original.Header().Clone(),
+		}
+
+		rw.ResponseWriter = tw
+
+		finished := make(chan *Panic, 1)
+
+		go func() {
+			defer func() {
+				value := recover()
+
+				if value != nil {
+					finished <- &Panic{Value: value, Stack: debug.Stack()}
+
+					return
+				}
+
+				finished <- nil
+			}()
+
+			next(r.WithContext(ctx))
+		}()
+
+		var p *Panic
+
+		expired := false
+
+		select {
+		case p = <-finished:
+		case <-ctx.Done():
+			expired = tw.expire()
+
+			if expired {
+				// the handler still writes through rw, so the timeout response goes to the original writer
+				out := &ResponseWriter{ResponseWriter: original, RequestID: rw.RequestID}
+
+				RenderError(out, r, problem.New(cfg.Status, ""))
+
+				flusher, ok := original.(http.Flusher)
+
+				if ok {
+					flusher.Flush()
+				}
+
+				defer func() {
+					rw.StatusCode = out.StatusCode
+
+					recordError(rw, http.ErrHandlerTimeout)
+				}()
+			}
+
+			p = <-finished
+		}
+
+		rw.ResponseWriter = original
+
+		if p == nil {
+			return
+		}
+
+		if expired {
+			logLatePanic(cfg, r, p)
+
+			return
+		}
+
+		panic(p.Value)
+	}
+}
+
+func WithTimeout(duration time.Duration) RouteOption {
+	return WithMetadata(timeoutKey, duration)
+}
+
+func saneTimeoutConfig(in TimeoutConfig) TimeoutConfig {
+	out := TimeoutConfig{
+		Duration: in.Duration,
+		Status:   http.StatusServiceUnavailable,
+		Output:   os.Stderr,
+	}
+
+	if in.Status != 0 {
+		out.Status = in.Status
+	}
+
+	if in.Output != nil {
+		out.Output = in.Output
+	}
+
+	return out
+}
+
+func logLatePanic(cfg TimeoutConfig, r *http.Request, p *Panic) {
+	if p.Value == http.ErrAbortHandler {
+		return
+	}
+
+	route, ok := RouteFromRequest(r)
+
+	if ok {
+		p.Route = route
+	}
+
+	_, _ = fmt.Fprintf(cfg.Output, "panic after timeout: %v | %s\n%s\n", p.Value, p.target(r), p.Stack)
+}
+
+func recordError(w http.ResponseWriter, err error) {
+	rw, ok := UnwrapResponseWriter(w)
+
+	if ok && !errors.Is(rw.Err, err) {
+		rw.Err = errors.Join(rw.Err, err)
+	}
+}
+
+type TimeoutConfig struct {
+	Duration time.Duration
+	Status   int
+	Output   io.Writer
+}
+
+type timeoutWriter struct {
+	http.ResponseWriter
+	mu        sync.Mutex
+	header    http.Header
+	committed bool
+	expired   bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(statusCode int) {
+	tw.mu.Lock()
+
+	defer tw.mu.Unlock()
+
+	if tw.expired || tw.committed {
+		return
+	}
+
+	tw.commit(statusCode)
+}
+
+func (tw *timeoutWriter) Write(buffer []byte) (int, error) {
+	tw.mu.Lock()
+
+	defer tw.mu.Unlock()
+
+	if tw.expired {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if !tw.committed {
+		tw.commit(http.StatusOK)
+	}
+
+	return tw.ResponseWriter.Write(buffer)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+
+	defer tw.mu.Unlock()
+
+	if tw.expired {
+		return
+	}
+
+	if !tw.committed {
+		tw.commit(http.StatusOK)
+	}
+
+	flusher, ok := tw.ResponseWriter.(http.Flusher)
+
+	if ok {
+		flusher.Flush()
+	}
+}
+
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+
+	defer tw.mu.Unlock()
+
+	if tw.expired {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+
+	hijacker, ok := tw.ResponseWriter.(http.Hijacker)
+
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	tw.committed = true
+
+	return hijacker.Hijack()
+}
+
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+func (tw *timeoutWriter) commit(statusCode int) {
+	header := tw.ResponseWriter.Header()
+
+	for key := range header {
+		delete(header, key)
+	}
+
+	for key, values := range tw.header {
+		header[key] = values
+	}
+
+	if statusCode >= 100 && statusCode < 200 {
+		tw.ResponseWriter.WriteHeader(statusCode)
+
+		return
+	}
+
+	tw.committed = true
+
+	tw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (tw *timeoutWriter) expire() bool {
+	tw.mu.Lock()
+
+	defer tw.mu.Unlock()
+
+	if tw.committed {
+		return false
+	}
+
+	tw.expired = true
+
+	return true
+}
+
+const timeoutKey = "http_timeout"
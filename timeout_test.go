@@ -0,0 +1,296 @@
+package http
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	slow := func(late chan error) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+
+			time.Sleep(10 * time.Millisecond)
+
+			w.Header().Set("X-Late", "true")
+
+			_, err := w.Write([]byte("late"))
+
+			late <- err
+		}
+	}
+
+	tests := []struct {
+		name       string
+		config     TimeoutConfig
+		opts       []RouteOption
+		handler    func(late chan error) http.HandlerFunc
+		wantStatus int
+		wantBody   string
+		wantLate   error
+	}{
+		{
+			name:   "should serve handlers that finish in time",
+			config: TimeoutConfig{Duration: time.Second},
+			handler: func(late chan error) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("X-Book", "7")
+
+					_, err := w.Write([]byte("ok"))
+
+					late <- err
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "should respond 503 and discard late writes",
+			config:     TimeoutConfig{Duration: 20 * time.Millisecond},
+			handler:    slow,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"instance":"/books/7","status":503,"title":"Service Unavailable"}`,
+			wantLate:   http.ErrHandlerTimeout,
+		},
+		{
+			name:       "should use the configured status",
+			config:     TimeoutConfig{Duration: 20 * time.Millisecond, Status: http.StatusGatewayTimeout},
+			handler:    slow,
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   `{"instance":"/books/7","status":504,"title":"Gateway Timeout"}`,
+			wantLate:   http.ErrHandlerTimeout,
+		},
+		{
+			name:       "should apply per route deadlines",
+			config:     TimeoutConfig{Duration: time.Hour},
+			opts:       []RouteOption{WithTimeout(20 * time.Millisecond)},
+			handler:    slow,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"instance":"/books/7","status":503,"title":"Service Unavailable"}`,
+			wantLate:   http.ErrHandlerTimeout,
+		},
+		{
+			name:   "should let routes opt out",
+			config: TimeoutConfig{Duration: 10 * time.Millisecond},
+			opts:   []RouteOption{WithTimeout(0)},
+			handler: func(late chan error) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(30 * time.Millisecond)
+
+					_, err := w.Write([]byte(fmt.Sprint(r.Context().Err())))
+
+					late <- err
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "<nil>",
+		},
+		{
+			name:   "should keep streaming once headers were sent",
+			config: TimeoutConfig{Duration: 20 * time.Millisecond},
+			handler: func(late chan error) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte("first "))
+
+					w.(http.Flusher).Flush()
+
+					<-r.Context().Done()
+
+					_, err := w.Write([]byte("second"))
+
+					late <- err
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "first second",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := NewMemoryWriter()
+
+			late := make(chan error, 1)
+
+			router := NewRouter()
+
+			router.Use(Logger(LoggerConfig{
+				Output: logs,
+				LogFormatter: func(params LogFormatterParams) string {
+					return params.Error
+				},
+			}))
+
+			router.Use(Timeout(tt.config))
+
+			router.GetFunc("/books/{id}", tt.handler(late), tt.opts...)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/7", nil))
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v", tt.wantStatus, w.Code)
+
+			if strings.HasPrefix(tt.wantBody, "{") {
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			} else {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+
+			err := <-late
+
+			assert.Equalf(t, tt.wantLate, err, "want %v, got %v", tt.wantLate, err)
+
+			assert.Emptyf(t, w.Header().Get("X-Late"), "want late headers discarded, got %v", w.Header().Get("X-Late"))
+
+			if tt.wantLate != nil {
+				assert.Equalf(t, http.ErrHandlerTimeout.Error(), logs.Content, "want the timeout logged, got %v", logs.Content)
+			}
+		})
+	}
+}
+
+func TestTimeout_Middleware(t *testing.T) {
+	t.Parallel()
+
+	late := make(chan error, 1)
+
+	router := NewRouter()
+
+	router.Use(Timeout(TimeoutConfig{Duration: 20 * time.Millisecond}))
+
+	router.Use(func(w http.ResponseWriter, r *http.Request, next Next) {
+		next(r)
+
+		w.Header().Set("X-Late", "true")
+
+		_, err := w.Write([]byte("late"))
+
+		late <- err
+	})
+
+	router.GetFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/7", nil))
+
+	assert.Equalf(t, http.StatusServiceUnavailable, w.Code, "want 503, got %v", w.Code)
+
+	err := <-late
+
+	assert.Equalf(t, http.ErrHandlerTimeout, err, "want %v, got %v", http.ErrHandlerTimeout, err)
+
+	assert.Emptyf(t, w.Header().Get("X-Late"), "want late headers discarded, got %v", w.Header().Get("X-Late"))
+}
+
+func TestTimeout_Panic(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Use(Recover(RecoverConfig{Output: io.Discard}))
+
+	router.Use(Timeout(TimeoutConfig{Duration: time.Second}))
+
+	router.GetFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		panic(errors.New("boom"))
+	})
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/7", nil))
+
+	assert.Equalf(t, http.StatusInternalServerError, w.Code, "want 500, got %v", w.Code)
+}
+
+func TestTimeout_LatePanic(t *testing.T) {
+	t.Parallel()
+
+	logged := make(chan string, 1)
+
+	router := NewRouter()
+
+	router.Use(Timeout(TimeoutConfig{Duration: 10 * time.Millisecond, Output: lineWriter(logged)}))
+
+	router.GetFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+
+		panic(errors.New("boom"))
+	})
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/7", nil))
+
+	assert.Equalf(t, http.StatusServiceUnavailable, w.Code, "want 503, got %v", w.Code)
+
+	select {
+	case got := <-logged:
+		want := "panic after timeout: boom | GET /books/{id}\n"
+
+		assert.Truef(t, strings.HasPrefix(got, want), "want prefix %q, got %q", want, got)
+	case <-time.After(time.Second):
+		t.Errorf("want late panic logged")
+	}
+}
+
+func TestTimeout_Hijack(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Use(Timeout(TimeoutConfig{Duration: time.Second}))
+
+	router.GetFunc("/upgrade", func(w http.ResponseWriter, r *http.Request) {
+		conn, buffer, err := http.NewResponseController(w).Hijack()
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		defer conn.Close()
+
+		_, _ = buffer.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+
+		_ = buffer.Flush()
+	})
+
+	server := httptest.NewServer(router)
+
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/upgrade")
+
+	assert.NoErrorf(t, err, "http.Get() err = %v", err)
+
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(bufio.NewReader(res.Body))
+
+	assert.Equalf(t, "hijacked", string(body), "want hijacked, got %v", string(body))
+}
+
+type lineWriter chan string
+
+func (lw lineWriter) Write(buffer []byte) (int, error) {
+	lw <- string(buffer)
+
+	return len(buffer), nil
+}
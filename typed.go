@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/aakash-rajur/http/bind"
+	"github.com/aakash-rajur/http/codec"
+	"github.com/aakash-rajur/http/internal/negotiate"
+	"github.com/aakash-rajur/http/render"
+	"net/http"
+	"reflect"
+)
+
+type TypedHandler interface {
+	http.Handler
+	RequestType() reflect.Type
+	ResponseType() reflect.Type
+}
+
+type StatusCoder interface {
+	StatusCode() int
+}
+
+func Typed[Req, Resp any](fn func(ctx context.Context, req Req) (Resp, error)) TypedHandler {
+	return &typed[Req, Resp]{fn: fn}
+}
+
+type typed[Req, Resp any] struct {
+	fn func(ctx context.Context, req Req) (Resp, error)
+}
+
+func (handler *typed[Req, Resp]) RequestType() reflect.Type {
+	return reflect.TypeOf((*Req)(nil)).Elem()
+}
+
+func (handler *typed[Req, Resp]) ResponseType() reflect.Type {
+	return reflect.TypeOf((*Resp)(nil)).Elem()
+}
+
+func (handler *typed[Req, Resp]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	accept := r.Header.Get("Accept")
+
+	_, ok := codec.Negotiate(accept)
+
+	if !ok {
+		HandleError(w, r, Errorf(http.StatusNotAcceptable, "none of %v is acceptable", codec.Default.MediaTypes()))
+
+		return
+	}
+
+	req, err := decodeRequest[Req](r)
+
+	if err != nil {
+		HandleError(w, r, err)
+
+		return
+	}
+
+	resp, err := handler.fn(r.Context(), req)
+
+	if err != nil {
+		HandleError(w, r, err)
+
+		return
+	}
+
+	status := http.StatusOK
+
+	coder, ok := any(resp).(StatusCoder)
+
+	if ok {
+		status = coder.StatusCode()
+	}
+
+	negotiate.AddVary(w.Header(), "Accept")
+
+	c, ok := codec.Default.NegotiateFor(accept, resp)
+
+	if !ok {
+		HandleError(w, r, Errorf(http.StatusNotAcceptable, "none of the acceptable media types can encode %T", resp))
+
+		return
+	}
+
+	var buf bytes.Buffer
+
+	err = c.Encode(&buf, resp)
+
+	if err != nil {
+		HandleError(w, r, err)
+
+		return
+	}
+
+	err = render.Bytes(w, status, c.MediaType(), buf.Bytes())
+
+	if err != nil {
+		HandleError(w, r, err)
+	}
+}
+
+func decodeRequest[Req any](r *http.Request) (Req, error) {
+	var req Req
+
+	target := reflect.ValueOf(&req).Elem()
+
+	if target.Kind() == reflect.Pointer {
+		target.Set(reflect.New(target.Type().Elem()))
+
+		target = target.Elem()
+	}
+
+	var err error
+
+	if target.Kind() == reflect.Struct {
+		err = bind.Bind(r, target.Addr().Interface())
+	} else {
+		err = bind.Body(r, target.Addr().Interface())
+	}
+
+	return req, bindError(err)
+}
+
+func bindError(err error) error {
+	if errors.Is(err, bind.ErrUnsupportedMediaType) {
+		return Errorf(http.StatusUnsupportedMediaType, "%w", err)
+	}
+
+	return err
+}
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type createBookRequest struct {
+	UserId int    `path:"userId" validate:"min=1"`
+	DryRun bool   `query:"dryRun"`
+	Name   string `json:"name" validate:"required"`
+}
+
+type bookResponse struct {
+	Id     int    `json:"id"`
+	UserId int    `json:"userId"`
+	Name   string `json:"name"`
+	DryRun bool   `json:"dryRun"`
+}
+
+func (bookResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+var errDuplicate = errors.New("duplicate book")
+
+func createBook(_ context.Context, req createBookRequest) (bookResponse, error) {
+	if req.Name == "Dune" {
+		return bookResponse{}, Errorf(http.StatusConflict, "%w: %s", errDuplicate, req.Name)
+	}
+
+	return bookResponse{Id: 1, UserId: req.UserId, Name: req.Name, DryRun: req.DryRun}, nil
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Post("/users/{userId}/books", Typed(createBook))
+
+	router.Put(
+		"/tags",
+		Typed(func(_ context.Context, tags []string) (map[string]int, error) {
+			return map[string]int{"count": len(tags)}, nil
+		}),
+	)
+
+	router.Get(
+		"/broken",
+		Typed(func(_ context.Context, _ struct{}) (map[string]any, error) {
+			return map[string]any{"done": make(chan struct{})}, nil
+		}),
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     map[string]string
+		body       string
+		wantStatus int
+		wantType   string
+		wantBody   string
+	}{
+		{
+			name:       "should bind, call and encode",
+			method:     http.MethodPost,
+			target:     "/users/7/books?dryRun=true",
+			header:     map[string]string{"Content-Type": "application/json"},
+			body:       `{"name":"Alchemist"}`,
+			wantStatus: http.StatusCreated,
+			wantType:   "application/json",
+			wantBody:   `{"id":1,"userId":7,"name":"Alchemist","dryRun":true}`,
+		},
+		{
+			name:       "should negotiate the response codec",
+			method:     http.MethodPost,
+			target:     "/users/7/books",
+			header:     map[string]string{"Content-Type": "application/json", "Accept": "application/xml"},
+			body:       `{"name":"Alchemist"}`,
+			wantStatus: http.StatusCreated,
+			wantType:   "application/xml",
+			wantBody:   `<bookResponse><Id>1</Id><UserId>7</UserId><Name>Alchemist</Name><DryRun>false</DryRun></bookResponse>`,
+		},
+		{
+			name:       "should report validation errors",
+			method:     http.MethodPost,
+			target:     "/users/0/books",
+			header:     map[string]string{"Content-Type": "application/json"},
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "application/problem+json",
+		},
+		{
+			name:       "should map handler errors",
+			method:     http.MethodPost,
+			target:     "/users/7/books",
+			header:     map[string]string{"Content-Type": "application/json"},
+			body:       `{"name":"Dune"}`,
+			wantStatus: http.StatusConflict,
+			wantType:   "application/problem+json",
+			wantBody:   `{"detail":"duplicate book: Dune","instance":"/users/7/books","status":409,"title":"Conflict"}`,
+		},
+		{
+			name:       "should reject unsupported bodies",
+			method:     http.MethodPost,
+			target:     "/users/7/books",
+			header:     map[string]string{"Content-Type": "text/csv"},
+			body:       `name\nDune`,
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantType:   "application/problem+json",
+		},
+		{
+			name:       "should reject unacceptable responses before calling",
+			method:     http.MethodPost,
+			target:     "/users/7/books",
+			header:     map[string]string{"Content-Type": "application/json", "Accept": "image/png"},
+			body:       `{"name":"Dune"}`,
+			wantStatus: http.StatusNotAcceptable,
+			wantType:   "application/problem+json",
+		},
+		{
+			name:       "should decode non struct requests",
+			method:     http.MethodPut,
+			target:     "/tags",
+			header:     map[string]string{"Content-Type": "application/json"},
+			body:       `["a","b","c"]`,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+			wantBody:   `{"count":3}`,
+		},
+		{
+			name:       "should negotiate among codecs that can encode the response",
+			method:     http.MethodPut,
+			target:     "/tags",
+			header:     map[string]string{"Content-Type": "application/json", "Accept": "application/xml, application/json;q=0.5"},
+			body:       `["a","b","c"]`,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+			wantBody:   `{"count":3}`,
+		},
+		{
+			name:       "should reject responses no acceptable codec can encode",
+			method:     http.MethodPut,
+			target:     "/tags",
+			header:     map[string]string{"Content-Type": "application/json", "Accept": "application/xml"},
+			body:       `["a","b","c"]`,
+			wantStatus: http.StatusNotAcceptable,
+			wantType:   "application/problem+xml",
+		},
+		{
+			name:       "should report encode errors as problems",
+			method:     http.MethodGet,
+			target:     "/broken",
+			header:     map[string]string{"Accept": "application/json"},
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "application/problem+json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "want %v, got %v: %v", tt.wantStatus, w.Code, w.Body.String())
+
+			assert.Equalf(t, tt.wantType, w.Header().Get("Content-Type"), "want %v, got %v", tt.wantType, w.Header().Get("Content-Type"))
+
+			switch {
+			case tt.wantBody == "":
+			case strings.HasPrefix(tt.wantBody, "{"):
+				assert.JSONEqf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			default:
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "want %v, got %v", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTyped_PointerRequest(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Post("/users/{userId}/books", Typed(func(_ context.Context, req *createBookRequest) (*bookResponse, error) {
+		return &bookResponse{Name: req.Name}, nil
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/users/3/books", strings.NewReader(`{"name":"Emma"}`))
+
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assert.Equalf(t, http.StatusCreated, w.Code, "want %v, got %v", http.StatusCreated, w.Code)
+
+	assert.JSONEqf(t, `{"id":0,"userId":0,"name":"Emma","dryRun":false}`, w.Body.String(), "got %v", w.Body.String())
+}
+
+func TestRouter_Routes(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.Post("/users/{userId}/books", Typed(createBook))
+
+	router.GetFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+
+	routes := router.Routes()
+
+	assert.Lenf(t, routes, 2, "want 2 routes, got %v", routes)
+
+	types := make(map[string][2]reflect.Type)
+
+	for _, route := range routes {
+		types[route.String()] = [2]reflect.Type{route.Request, route.Response}
+	}
+
+	expected := map[string][2]reflect.Type{
+		"POST /users/{userId}/books": {reflect.TypeOf(createBookRequest{}), reflect.TypeOf(bookResponse{})},
+		"GET /health":                {nil, nil},
+	}
+
+	assert.Equalf(t, expected, types, "want %v, got %v", expected, types)
+}
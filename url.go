@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aakash-rajur/http/params"
+	"github.com/aakash-rajur/http/register"
+	"net/url"
+	"strings"
+)
+
+func (router *Router) NamedRoute(name string) (*Route, bool) {
+	route, ok := router.state.Load().names[name]
+
+	return route, ok
+}
+
+func (router *Router) URL(name string, values params.Params) (string, error) {
+	state := router.state.Load()
+
+	route, ok := state.names[name]
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownRoute, name)
+	}
+
+	partials := strings.Split(register.Normalize(route.Pattern, state.dialect), "/")
+
+	for i, partial := range partials {
+		if partial == "{$}" {
+			partials[i] = ""
+
+			continue
+		}
+
+		if !strings.HasPrefix(partial, "{") || !strings.HasSuffix(partial, "}") {
+			continue
+		}
+
+		key := strings.TrimSuffix(partial[1:len(partial)-1], "...")
+
+		value, ok := values[key]
+
+		if !ok {
+			return "", fmt.Errorf("%w: %s needs {%s}", ErrMissingParam, name, key)
+		}
+
+		if strings.HasSuffix(partial, "...}") {
+			partials[i] = escapeSegments(value)
+
+			continue
+		}
+
+		partials[i] = url.PathEscape(value)
+	}
+
+	return strings.Join(partials, "/"), nil
+}
+
+func (router *Router) paramSegment(name string) string {
+	if router.state.Load().dialect&DialectBraces == 0 {
+		return ":" + name
+	}
+
+	return "{" + name + "}"
+}
+
+func escapeSegments(value string) string {
+	segments := strings.Split(value, "/")
+
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+var (
+	ErrUnknownRoute = errors.New("unknown route")
+	ErrMissingParam = errors.New("missing route param")
+)
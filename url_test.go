@@ -0,0 +1,142 @@
+package http
+
+import (
+	"errors"
+	"github.com/aakash-rajur/http/params"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRouter_URL(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	router := NewRouter()
+
+	router.GetFunc("/users/{userId}/books/{id}", handler, WithName("book"))
+
+	router.GetFunc("/static/*filepath", handler, WithName("static"))
+
+	router.GetFunc("/{$}", handler, WithName("home"))
+
+	tests := []struct {
+		name    string
+		route   string
+		values  params.Params
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "should substitute params",
+			route:  "book",
+			values: params.Params{"userId": "7", "id": "a b"},
+			want:   "/users/7/books/a%20b",
+		},
+		{
+			name:   "should keep catch-all slashes",
+			route:  "static",
+			values: params.Params{"filepath": "css/site main.css"},
+			want:   "/static/css/site%20main.css",
+		},
+		{
+			name:   "should drop the anchor",
+			route:  "home",
+			values: params.Params{},
+			want:   "/",
+		},
+		{
+			name:    "should report missing params",
+			route:   "book",
+			values:  params.Params{"userId": "7"},
+			wantErr: ErrMissingParam,
+		},
+		{
+			name:    "should report unknown routes",
+			route:   "author",
+			wantErr: ErrUnknownRoute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := router.URL(tt.route, tt.values)
+
+			if tt.wantErr != nil {
+				assert.Truef(t, errors.Is(err, tt.wantErr), "want %v, got %v", tt.wantErr, err)
+
+				return
+			}
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.Equalf(t, tt.want, got, "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestRouter_DuplicateName(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	router.GetFunc("/a", func(w http.ResponseWriter, r *http.Request) {}, WithName("a"))
+
+	assert.Panicsf(t, func() {
+		router.GetFunc("/b", func(w http.ResponseWriter, r *http.Request) {}, WithName("a"))
+	}, "want duplicate route names to panic")
+}
+
+func TestRouter_URL_PendingRegistration(t *testing.T) {
+	t.Parallel()
+
+	router := NewRouter()
+
+	started, registering := make(chan struct{}), make(chan struct{})
+
+	router.GetFunc("/books/:id", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+
+		<-registering
+
+		time.Sleep(20 * time.Millisecond)
+
+		url, err := router.URL("book", params.Params{"id": "1"})
+
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(url))
+	}, WithName("book"))
+
+	go func() {
+		<-started
+
+		close(registering)
+
+		router.GetFunc("/authors", func(w http.ResponseWriter, r *http.Request) {})
+	}()
+
+	done := make(chan string)
+
+	go func() {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/1", nil))
+
+		done <- w.Body.String()
+	}()
+
+	select {
+	case got := <-done:
+		assert.Equalf(t, "/books/1", got, "want %v, got %v", "/books/1", got)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("want the url built while a registration is pending, got a deadlock")
+	}
+}
@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type FieldError struct {
+	Field   string `json:"field" xml:"field" yaml:"field"`
+	Source  string `json:"source,omitempty" xml:"source,omitempty" yaml:"source,omitempty"`
+	Rule    string `json:"rule" xml:"rule" yaml:"rule"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty" yaml:"param,omitempty"`
+	Message string `json:"message" xml:"message" yaml:"message"`
+}
+
+func (fe FieldError) Error() string {
+	if fe.Source == "" {
+		return fmt.Sprintf("%s %s", fe.Field, fe.Message)
+	}
+
+	return fmt.Sprintf("%s %s %s", fe.Source, fe.Field, fe.Message)
+}
+
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, each := range e {
+		messages[i] = each.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e Errors) Status() int {
+	return http.StatusBadRequest
+}
+
+func (e Errors) Fields() map[string][]string {
+	fields := make(map[string][]string)
+
+	for _, each := range e {
+		fields[each.Field] = append(fields[each.Field], each.Message)
+	}
+
+	return fields
+}
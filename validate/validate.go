@@ -0,0 +1,276 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func Struct(value any) error {
+	v := reflect.ValueOf(value)
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	errs := validateStruct(v, "")
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func validateStruct(v reflect.Value, prefix string) Errors {
+	errs := make(Errors, 0)
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i += 1 {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, validateStruct(fv, prefix)...)
+
+			continue
+		}
+
+		name := prefix + FieldName(field)
+
+		rules := field.Tag.Get(tagName)
+
+		if rules != "" && rules != "-" {
+			errs = append(errs, validateField(fv, name, rules)...)
+		}
+
+		nested := fv
+
+		for nested.Kind() == reflect.Pointer && !nested.IsNil() {
+			nested = nested.Elem()
+		}
+
+		if nested.Kind() == reflect.Struct && nested.Type() != timeType {
+			errs = append(errs, validateStruct(nested, name+".")...)
+		}
+	}
+
+	return errs
+}
+
+func validateField(v reflect.Value, name, rules string) Errors {
+	errs := make(Errors, 0)
+
+	parsed := splitRules(rules)
+
+	if slices.Contains(parsed, "omitempty") && isZero(v) {
+		return errs
+	}
+
+	for _, rule := range parsed {
+		key, param, _ := strings.Cut(rule, "=")
+
+		if key == "omitempty" {
+			continue
+		}
+
+		check, ok := checks[key]
+
+		if !ok {
+			errs = append(errs, FieldError{Field: name, Rule: key, Param: param, Message: "unknown rule"})
+
+			continue
+		}
+
+		message := check(deref(v), param)
+
+		if message == "" {
+			continue
+		}
+
+		errs = append(errs, FieldError{Field: name, Rule: key, Param: param, Message: message})
+
+		if key == "required" {
+			break
+		}
+	}
+
+	return errs
+}
+
+func splitRules(rules string) []string {
+	parts := make([]string, 0)
+
+	for _, part := range strings.Split(rules, ",") {
+		last := len(parts) - 1
+
+		// regex parameters may contain commas, keep them with the previous rule
+		if last > -1 && strings.HasPrefix(parts[last], "regex=") && !strings.Contains(part, "=") {
+			parts[last] += "," + part
+
+			continue
+		}
+
+		part = strings.TrimSpace(part)
+
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return parts
+}
+
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "xml", "form", "query", "path", "header", "cookie"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+type check func(v reflect.Value, param string) string
+
+var checks = map[string]check{
+	"required": func(v reflect.Value, _ string) string {
+		if isZero(v) {
+			return "is required"
+		}
+
+		return ""
+	},
+	"min": func(v reflect.Value, param string) string {
+		return compare(v, param, func(a, b float64) bool { return a >= b }, "must be at least %s")
+	},
+	"max": func(v reflect.Value, param string) string {
+		return compare(v, param, func(a, b float64) bool { return a <= b }, "must be at most %s")
+	},
+	"len": func(v reflect.Value, param string) string {
+		return compare(v, param, func(a, b float64) bool { return a == b }, "must have length %s")
+	},
+	"regex": func(v reflect.Value, param string) string {
+		re, err := compile(param)
+
+		if err != nil {
+			return fmt.Sprintf("invalid pattern %q", param)
+		}
+
+		if re.MatchString(fmt.Sprint(v.Interface())) {
+			return ""
+		}
+
+		return fmt.Sprintf("must match %s", param)
+	},
+	"enum": func(v reflect.Value, param string) string {
+		options := strings.Split(param, "|")
+
+		if slices.Contains(options, fmt.Sprint(v.Interface())) {
+			return ""
+		}
+
+		return fmt.Sprintf("must be one of %s", strings.Join(options, ", "))
+	},
+}
+
+func compare(v reflect.Value, param string, ok func(a, b float64) bool, message string) string {
+	limit, err := strconv.ParseFloat(param, 64)
+
+	if err != nil {
+		return fmt.Sprintf("invalid limit %q", param)
+	}
+
+	var measure float64
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		measure = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		measure = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		measure = v.Float()
+	case reflect.String:
+		measure = float64(len([]rune(v.String())))
+	case reflect.Slice, reflect.Map, reflect.Array:
+		measure = float64(v.Len())
+	default:
+		return fmt.Sprintf("cannot measure %s", v.Type())
+	}
+
+	if ok(measure, limit) {
+		return ""
+	}
+
+	return fmt.Sprintf(message, param)
+}
+
+func compile(expr string) (*regexp.Regexp, error) {
+	cached, ok := patterns.Load(expr)
+
+	if ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(expr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	patterns.Store(expr, re)
+
+	return re, nil
+}
+
+func isZero(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func deref(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+var patterns sync.Map
+
+var timeType = reflect.TypeOf(time.Time{})
+
+const tagName = "validate"
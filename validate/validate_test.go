@@ -0,0 +1,133 @@
+package validate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+
+	type Book struct {
+		Name     string   `json:"name" validate:"required,min=2,max=10"`
+		Rating   int      `json:"rating" validate:"omitempty,min=1,max=5"`
+		Isbn     string   `json:"isbn" validate:"omitempty,regex=^[0-9]{3}-[0-9]{1,10}$"`
+		Format   string   `json:"format" validate:"omitempty,enum=hardcover|paperback"`
+		Tags     []string `json:"tags" validate:"omitempty,max=2"`
+		Pages    *int     `json:"pages" validate:"omitempty,min=1"`
+		Address  Address  `json:"address"`
+		Untagged string
+	}
+
+	zero := 0
+
+	tests := []struct {
+		name  string
+		value any
+		want  Errors
+	}{
+		{
+			name: "should pass valid struct",
+			value: &Book{
+				Name:    "Alchemist",
+				Rating:  5,
+				Isbn:    "978-0062315007",
+				Format:  "paperback",
+				Tags:    []string{"fiction"},
+				Address: Address{City: "Bengaluru"},
+			},
+			want: nil,
+		},
+		{
+			name: "should aggregate failures",
+			value: Book{
+				Name:   "A",
+				Rating: 6,
+				Isbn:   "isbn",
+				Format: "ebook",
+				Tags:   []string{"a", "b", "c"},
+				Pages:  &zero,
+			},
+			want: Errors{
+				{Field: "name", Rule: "min", Param: "2", Message: "must be at least 2"},
+				{Field: "rating", Rule: "max", Param: "5", Message: "must be at most 5"},
+				{Field: "isbn", Rule: "regex", Param: "^[0-9]{3}-[0-9]{1,10}$", Message: "must match ^[0-9]{3}-[0-9]{1,10}$"},
+				{Field: "format", Rule: "enum", Param: "hardcover|paperback", Message: "must be one of hardcover, paperback"},
+				{Field: "tags", Rule: "max", Param: "2", Message: "must be at most 2"},
+				{Field: "pages", Rule: "min", Param: "1", Message: "must be at least 1"},
+				{Field: "address.city", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name:  "should report required",
+			value: &Book{Address: Address{City: "Pune"}},
+			want: Errors{
+				{Field: "name", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "should check zero values without omitempty",
+			value: struct {
+				Page  int  `json:"page" validate:"min=1,max=100"`
+				Size  *int `json:"size" validate:"min=1"`
+				Limit int  `json:"limit" validate:"omitempty,min=1"`
+			}{},
+			want: Errors{
+				{Field: "page", Rule: "min", Param: "1", Message: "must be at least 1"},
+				{Field: "size", Rule: "min", Param: "1", Message: "must be at least 1"},
+			},
+		},
+		{
+			name:  "should ignore non structs",
+			value: 10,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.value)
+
+			if tt.want == nil {
+				assert.NoErrorf(t, err, "Struct() err = %v", err)
+
+				return
+			}
+
+			assert.Equalf(t, tt.want, err, "Struct() = %v, want %v", err, tt.want)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	errs := Errors{
+		{Field: "name", Source: "body", Rule: "required", Message: "is required"},
+		{Field: "name", Source: "body", Rule: "min", Message: "must be at least 2"},
+		{Field: "id", Rule: "type", Message: "must be a number"},
+	}
+
+	assert.Equalf(
+		t,
+		"body name is required; body name must be at least 2; id must be a number",
+		errs.Error(),
+		"Error() = %v",
+		errs.Error(),
+	)
+
+	assert.Equalf(t, http.StatusBadRequest, errs.Status(), "Status() = %v", errs.Status())
+
+	assert.Equalf(
+		t,
+		map[string][]string{"name": {"is required", "must be at least 2"}, "id": {"must be a number"}},
+		errs.Fields(),
+		"Fields() = %v",
+		errs.Fields(),
+	)
+}
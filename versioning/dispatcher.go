@@ -0,0 +1,62 @@
+package versioning
+
+import (
+	"context"
+	"fmt"
+	h "github.com/aakash-rajur/http"
+	"github.com/aakash-rajur/http/problem"
+	"net/http"
+)
+
+type dispatcher struct {
+	versioning *Versioning
+	method     string
+	pattern    string
+	index      int
+}
+
+func (d *dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v := d.versioning
+
+	index := d.index
+
+	if index < 0 {
+		index = v.fromRequest(r.Header.Get(v.config.Header), r.Header.Values("Accept"))
+	}
+
+	if index < 0 {
+		index = v.defaultIndex()
+	}
+
+	group, resolved := v.resolve(index, d.method, d.pattern)
+
+	if resolved == nil {
+		details := problem.New(http.StatusNotFound, "route is not served by the requested api version")
+
+		details.Instance = r.URL.Path
+
+		h.RenderError(w, r, details)
+
+		return
+	}
+
+	lifecycleHeaders(w.Header(), group.version)
+
+	ctx := context.WithValue(r.Context(), versionKey, group.version.Name)
+
+	resolved.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func lifecycleHeaders(header http.Header, version Version) {
+	if !version.Deprecated.IsZero() {
+		header.Set("Deprecation", fmt.Sprintf("@%d", version.Deprecated.Unix()))
+	}
+
+	if !version.Sunset.IsZero() {
+		header.Set("Sunset", version.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if version.Link != "" && (!version.Deprecated.IsZero() || !version.Sunset.IsZero()) {
+		header.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", version.Link))
+	}
+}
@@ -0,0 +1,111 @@
+package versioning
+
+import (
+	h "github.com/aakash-rajur/http"
+	"net/http"
+)
+
+type Group struct {
+	versioning *Versioning
+	version    Version
+	index      int
+	routes     map[string]route
+}
+
+func (g *Group) Name() string {
+	return g.version.Name
+}
+
+func (g *Group) HandleMethod(method, pattern string, handler http.Handler, opts ...h.RouteOption) {
+	r := route{
+		method:  method,
+		pattern: pattern,
+		handler: handler,
+		opts:    opts,
+	}
+
+	g.versioning.handle(g, r)
+}
+
+func (g *Group) HandleMethodFunc(method, pattern string, handlerFunc http.HandlerFunc, opts ...h.RouteOption) {
+	g.HandleMethod(method, pattern, handlerFunc, opts...)
+}
+
+func (g *Group) HandleMethodE(method, pattern string, handler h.HandlerE, opts ...h.RouteOption) {
+	g.HandleMethod(method, pattern, handler, opts...)
+}
+
+func (g *Group) Get(pattern string, handler http.Handler, opts ...h.RouteOption) {
+	g.HandleMethod(http.MethodGet, pattern, handler, opts...)
+}
+
+func (g *Group) GetFunc(pattern string, handlerFunc http.HandlerFunc, opts ...h.RouteOption) {
+	g.Get(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) Post(pattern string, handler http.Handler, opts ...h.RouteOption) {
+	g.HandleMethod(http.MethodPost, pattern, handler, opts...)
+}
+
+func (g *Group) PostFunc(pattern string, handlerFunc http.HandlerFunc, opts ...h.RouteOption) {
+	g.Post(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) Put(pattern string, handler http.Handler, opts ...h.RouteOption) {
+	g.HandleMethod(http.MethodPut, pattern, handler, opts...)
+}
+
+func (g *Group) PutFunc(pattern string, handlerFunc http.HandlerFunc, opts ...h.RouteOption) {
+	g.Put(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) Patch(pattern string, handler http.Handler, opts ...h.RouteOption) {
+	g.HandleMethod(http.MethodPatch, pattern, handler, opts...)
+}
+
+func (g *Group) PatchFunc(pattern string, handlerFunc http.HandlerFunc, opts ...h.RouteOption) {
+	g.Patch(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) Delete(pattern string, handler http.Handler, opts ...h.RouteOption) {
+	g.HandleMethod(http.MethodDelete, pattern, handler, opts...)
+}
+
+func (g *Group) DeleteFunc(pattern string, handlerFunc http.HandlerFunc, opts ...h.RouteOption) {
+	g.Delete(pattern, handlerFunc, opts...)
+}
+
+func (g *Group) GetE(pattern string, handler h.HandlerE, opts ...h.RouteOption) {
+	g.Get(pattern, handler, opts...)
+}
+
+func (g *Group) PostE(pattern string, handler h.HandlerE, opts ...h.RouteOption) {
+	g.Post(pattern, handler, opts...)
+}
+
+func (g *Group) PutE(pattern string, handler h.HandlerE, opts ...h.RouteOption) {
+	g.Put(pattern, handler, opts...)
+}
+
+func (g *Group) PatchE(pattern string, handler h.HandlerE, opts ...h.RouteOption) {
+	g.Patch(pattern, handler, opts...)
+}
+
+func (g *Group) DeleteE(pattern string, handler h.HandlerE, opts ...h.RouteOption) {
+	g.Delete(pattern, handler, opts...)
+}
+
+func (g *Group) Resource(pattern string, controller any, opts ...h.ResourceOption) *h.Resource {
+	return h.NewResource(g, pattern, controller, opts...)
+}
+
+type route struct {
+	method  string
+	pattern string
+	handler http.Handler
+	opts    []h.RouteOption
+}
+
+func (r route) key() string {
+	return r.method + " " + r.pattern
+}
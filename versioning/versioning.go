@@ -0,0 +1,291 @@
+package versioning
+
+import (
+	h "github.com/aakash-rajur/http"
+	"net/http"
+	p "path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+func New(router *h.Router, config Config) *Versioning {
+	cfg := saneConfig(config)
+
+	v := &Versioning{
+		config:     cfg,
+		router:     router,
+		versions:   make([]*Group, 0),
+		registered: make(map[string]bool),
+		vendor:     vendorPattern(cfg.Vendor),
+	}
+
+	return v
+}
+
+func saneConfig(in Config) Config {
+	out := Config{
+		Prefix: "/",
+		Header: "Accept-Version",
+	}
+
+	if strings.TrimSpace(in.Prefix) != "" {
+		out.Prefix = in.Prefix
+	}
+
+	if strings.TrimSpace(in.Header) != "" {
+		out.Header = in.Header
+	}
+
+	out.Vendor = in.Vendor
+
+	out.Default = in.Default
+
+	return out
+}
+
+type Config struct {
+	Prefix  string
+	Header  string
+	Vendor  string
+	Default string
+}
+
+type Version struct {
+	Name       string
+	Deprecated time.Time
+	Sunset     time.Time
+	Link       string
+}
+
+type Versioning struct {
+	mu         sync.RWMutex
+	config     Config
+	router     *h.Router
+	versions   []*Group
+	registered map[string]bool
+	vendor     *regexp.Regexp
+}
+
+func (v *Versioning) Version(version Version) *Group {
+	v.mu.Lock()
+
+	group := &Group{
+		versioning: v,
+		version:    version,
+		index:      len(v.versions),
+		routes:     make(map[string]route),
+	}
+
+	v.versions = append(v.versions, group)
+
+	inherited := make([]route, 0)
+
+	for _, older := range v.versions[:group.index] {
+		for _, each := range older.routes {
+			inherited = append(inherited, each)
+		}
+	}
+
+	v.mu.Unlock()
+
+	for _, each := range inherited {
+		v.register(group.index, each)
+	}
+
+	return group
+}
+
+func (v *Versioning) handle(group *Group, r route) {
+	v.mu.Lock()
+
+	group.routes[r.key()] = r
+
+	count := len(v.versions)
+
+	v.mu.Unlock()
+
+	for index := group.index; index < count; index += 1 {
+		v.register(index, r)
+	}
+
+	v.registerUnversioned(r)
+}
+
+func (v *Versioning) register(index int, r route) {
+	v.mu.Lock()
+
+	group := v.versions[index]
+
+	key := group.version.Name + " " + r.key()
+
+	if v.registered[key] {
+		v.mu.Unlock()
+
+		return
+	}
+
+	v.registered[key] = true
+
+	v.mu.Unlock()
+
+	pattern := p.Join(v.config.Prefix, group.version.Name, r.pattern)
+
+	handler := &dispatcher{
+		versioning: v,
+		method:     r.method,
+		pattern:    r.pattern,
+		index:      index,
+	}
+
+	v.router.HandleMethod(r.method, pattern, handler, versionedOptions(group.version.Name, r.opts)...)
+}
+
+func (v *Versioning) registerUnversioned(r route) {
+	v.mu.Lock()
+
+	key := r.key()
+
+	if v.registered[key] {
+		v.mu.Unlock()
+
+		return
+	}
+
+	v.registered[key] = true
+
+	v.mu.Unlock()
+
+	pattern := p.Join(v.config.Prefix, r.pattern)
+
+	handler := &dispatcher{
+		versioning: v,
+		method:     r.method,
+		pattern:    r.pattern,
+		index:      -1,
+	}
+
+	v.router.HandleMethod(r.method, pattern, handler, r.opts...)
+}
+
+func (v *Versioning) resolve(index int, method, pattern string) (*Group, *route) {
+	v.mu.RLock()
+
+	defer v.mu.RUnlock()
+
+	if index < 0 || index >= len(v.versions) {
+		return nil, nil
+	}
+
+	group := v.versions[index]
+
+	key := method + " " + pattern
+
+	for i := index; i > -1; i -= 1 {
+		candidate, ok := v.versions[i].routes[key]
+
+		if ok {
+			return group, &candidate
+		}
+	}
+
+	return group, nil
+}
+
+func (v *Versioning) indexOf(name string) int {
+	v.mu.RLock()
+
+	defer v.mu.RUnlock()
+
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "" {
+		return -1
+	}
+
+	for index, group := range v.versions {
+		candidate := strings.ToLower(group.version.Name)
+
+		if candidate == name || candidate == "v"+name {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (v *Versioning) defaultIndex() int {
+	index := v.indexOf(v.config.Default)
+
+	if index > -1 {
+		return index
+	}
+
+	v.mu.RLock()
+
+	defer v.mu.RUnlock()
+
+	return len(v.versions) - 1
+}
+
+func (v *Versioning) fromRequest(headerValue string, accept []string) int {
+	index := v.indexOf(headerValue)
+
+	if index > -1 || v.vendor == nil {
+		return index
+	}
+
+	for _, each := range accept {
+		matches := v.vendor.FindAllStringSubmatch(each, -1)
+
+		for _, match := range matches {
+			index = v.indexOf(match[1])
+
+			if index > -1 {
+				return index
+			}
+		}
+	}
+
+	return -1
+}
+
+func vendorPattern(vendor string) *regexp.Regexp {
+	if strings.TrimSpace(vendor) == "" {
+		return nil
+	}
+
+	expr := `(?i)application/vnd\.` + regexp.QuoteMeta(vendor) + `\.([a-z0-9_.-]+?)(?:\+[a-z0-9]+)?(?:\s*[;,]|$)`
+
+	return regexp.MustCompile(expr)
+}
+
+func FromRequest(r *http.Request) (string, bool) {
+	ctx := r.Context()
+
+	if ctx == nil {
+		return "", false
+	}
+
+	version, ok := ctx.Value(versionKey).(string)
+
+	return version, ok
+}
+
+const versionKey = "http_api_version"
+
+func versionedOptions(version string, opts []h.RouteOption) []h.RouteOption {
+	probe := &h.Route{}
+
+	for _, opt := range opts {
+		opt(probe)
+	}
+
+	if probe.Name == "" {
+		return opts
+	}
+
+	return append(slices.Clone(opts), h.WithName(version+"."+probe.Name))
+}
@@ -0,0 +1,229 @@
+package versioning
+
+import (
+	"fmt"
+	h "github.com/aakash-rajur/http"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVersioning(t *testing.T) {
+	t.Parallel()
+
+	deprecated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sunset := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	respond := func(label string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			version, _ := FromRequest(r)
+
+			w.WriteHeader(http.StatusOK)
+
+			_, _ = w.Write([]byte(fmt.Sprintf("%s@%s", label, version)))
+		}
+	}
+
+	router := h.NewRouter()
+
+	api := New(router, Config{Prefix: "/api", Vendor: "x"})
+
+	v2 := api.Version(
+		Version{
+			Name:       "v2",
+			Deprecated: deprecated,
+			Sunset:     sunset,
+			Link:       "https://example.com/migrate",
+		},
+	)
+
+	v3 := api.Version(Version{Name: "v3"})
+
+	v2.GetFunc("/books", respond("books-v2"))
+
+	v2.GetFunc("/books/{id}", respond("book-v2"))
+
+	v3.GetFunc("/books", respond("books-v3"))
+
+	v3.GetFunc("/authors", respond("authors-v3"))
+
+	v4 := api.Version(Version{Name: "v4"})
+
+	v4.PostFunc("/authors", respond("create-author-v4"))
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		header         map[string]string
+		wantStatus     int
+		wantBody       string
+		wantDeprecated bool
+	}{
+		{
+			name:           "should serve deprecated version by path",
+			method:         http.MethodGet,
+			target:         "/api/v2/books",
+			wantStatus:     http.StatusOK,
+			wantBody:       "books-v2@v2",
+			wantDeprecated: true,
+		},
+		{
+			name:       "should serve newer version by path",
+			method:     http.MethodGet,
+			target:     "/api/v3/books",
+			wantStatus: http.StatusOK,
+			wantBody:   "books-v3@v3",
+		},
+		{
+			name:       "should inherit routes from older versions",
+			method:     http.MethodGet,
+			target:     "/api/v3/books/10",
+			wantStatus: http.StatusOK,
+			wantBody:   "book-v2@v3",
+		},
+		{
+			name:       "should inherit into versions declared later",
+			method:     http.MethodGet,
+			target:     "/api/v4/authors",
+			wantStatus: http.StatusOK,
+			wantBody:   "authors-v3@v4",
+		},
+		{
+			name:       "should not expose newer routes to older versions",
+			method:     http.MethodGet,
+			target:     "/api/v2/authors",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should resolve version from header",
+			method:         http.MethodGet,
+			target:         "/api/books",
+			header:         map[string]string{"Accept-Version": "2"},
+			wantStatus:     http.StatusOK,
+			wantBody:       "books-v2@v2",
+			wantDeprecated: true,
+		},
+		{
+			name:       "should resolve version from vendor media type",
+			method:     http.MethodGet,
+			target:     "/api/books",
+			header:     map[string]string{"Accept": "application/vnd.x.v3+json"},
+			wantStatus: http.StatusOK,
+			wantBody:   "books-v3@v3",
+		},
+		{
+			name:       "should default to latest version",
+			method:     http.MethodGet,
+			target:     "/api/books",
+			wantStatus: http.StatusOK,
+			wantBody:   "books-v3@v4",
+		},
+		{
+			name:       "should respond 404 when version lacks route",
+			method:     http.MethodGet,
+			target:     "/api/authors",
+			header:     map[string]string{"Accept-Version": "v2"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should serve routes added to newest version",
+			method:     http.MethodPost,
+			target:     "/api/v4/authors",
+			wantStatus: http.StatusOK,
+			wantBody:   "create-author-v4@v4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equalf(t, tt.wantStatus, w.Code, "code should be %d", tt.wantStatus)
+
+			if tt.wantBody != "" {
+				assert.Equalf(t, tt.wantBody, w.Body.String(), "body should be %s", tt.wantBody)
+			}
+
+			if !tt.wantDeprecated {
+				assert.Emptyf(t, w.Header().Get("Deprecation"), "should not emit Deprecation")
+
+				return
+			}
+
+			assert.Equalf(t, "@1704067200", w.Header().Get("Deprecation"), "should emit Deprecation")
+
+			assert.Equalf(t, "Wed, 01 Jan 2025 00:00:00 GMT", w.Header().Get("Sunset"), "should emit Sunset")
+
+			assert.Equalf(
+				t,
+				`<https://example.com/migrate>; rel="deprecation"`,
+				w.Header().Get("Link"),
+				"should emit Link",
+			)
+		})
+	}
+}
+
+type authorController struct{}
+
+func (authorController) Index(w http.ResponseWriter, r *http.Request) error {
+	version, _ := FromRequest(r)
+
+	_, err := w.Write([]byte("authors@" + version))
+
+	return err
+}
+
+func TestGroup_Resource(t *testing.T) {
+	t.Parallel()
+
+	router := h.NewRouter()
+
+	api := New(router, Config{Prefix: "/api"})
+
+	v1 := api.Version(Version{Name: "v1"})
+
+	v2 := api.Version(Version{Name: "v2"})
+
+	v1.Resource("/authors", authorController{})
+
+	v2.Resource("/authors", authorController{})
+
+	tests := []struct {
+		name     string
+		route    string
+		wantPath string
+	}{
+		{name: "unversioned", route: "authors.index", wantPath: "/api/authors"},
+		{name: "v1", route: "v1.authors.index", wantPath: "/api/v1/authors"},
+		{name: "v2", route: "v2.authors.index", wantPath: "/api/v2/authors"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := router.URL(tt.route, nil)
+
+			assert.NoErrorf(t, err, "want no error, got %v", err)
+
+			assert.Equalf(t, tt.wantPath, path, "want %v, got %v", tt.wantPath, path)
+		})
+	}
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/authors", nil))
+
+	assert.Equalf(t, "authors@v1", w.Body.String(), "want %v, got %v", "authors@v1", w.Body.String())
+}